@@ -0,0 +1,61 @@
+package structtable
+
+import "reflect"
+
+// ColumnInfo describes a column that a ColumnMapper
+// produces for a struct type.
+type ColumnInfo struct {
+	Title string
+	// Type is the Go type of the column values,
+	// or nil if it could not be determined
+	Type reflect.Type
+}
+
+// AnalyzeColumns returns the columns that columnMapper produces
+// for structType without rendering any data,
+// for example to let users pick and rename columns
+// or choose formatters in a UI.
+//
+// The column types are determined by reflecting a zero value
+// of structType with all anonymously embedded struct pointers
+// allocated, so every column gets the type of its originating field.
+// Title is empty for columns without titles, like from NoColumnTitles.
+func AnalyzeColumns(structType reflect.Type, columnMapper ColumnMapper) []ColumnInfo {
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	titles, rowReflector := columnMapper.ColumnTitlesAndRowReflector(structType)
+	columnValues := rowReflector.ReflectRow(newAnalyzeStruct(structType))
+
+	columns := make([]ColumnInfo, max(len(titles), len(columnValues)))
+	for i := range columns {
+		if i < len(titles) {
+			columns[i].Title = titles[i]
+		}
+		if i < len(columnValues) && columnValues[i].IsValid() {
+			columns[i].Type = columnValues[i].Type()
+		}
+	}
+	return columns
+}
+
+// newAnalyzeStruct returns a new zero value of structType
+// with all anonymously embedded struct pointers allocated.
+func newAnalyzeStruct(structType reflect.Type) reflect.Value {
+	structValue := reflect.New(structType).Elem()
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		if !field.Anonymous || !structValue.Field(i).CanSet() {
+			continue
+		}
+		switch {
+		case field.Type.Kind() == reflect.Ptr && field.Type.Elem().Kind() == reflect.Struct:
+			embedded := reflect.New(field.Type.Elem())
+			embedded.Elem().Set(newAnalyzeStruct(field.Type.Elem()))
+			structValue.Field(i).Set(embedded)
+		case field.Type.Kind() == reflect.Struct:
+			structValue.Field(i).Set(newAnalyzeStruct(field.Type))
+		}
+	}
+	return structValue
+}
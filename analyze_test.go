@@ -0,0 +1,40 @@
+package structtable
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type AnalyzeEmbedded struct {
+	Created time.Time
+}
+
+func TestAnalyzeColumns(t *testing.T) {
+	type row struct {
+		Name  string `col:"Full Name"`
+		Count *int
+		Skip  bool `col:"-"`
+		*AnalyzeEmbedded
+		Value any
+	}
+	structType := reflect.TypeFor[row]()
+
+	columns := AnalyzeColumns(structType, DefaultReflectColumnTitles)
+	assert.Equal(t, []ColumnInfo{
+		{Title: "Full Name", Type: reflect.TypeFor[string]()},
+		{Title: "Count", Type: reflect.TypeFor[*int]()},
+		{Title: "Created", Type: reflect.TypeFor[time.Time]()},
+		{Title: "Value", Type: reflect.TypeFor[any]()},
+	}, columns)
+
+	columns = AnalyzeColumns(reflect.PointerTo(structType), WithRowNumberColumn(DefaultReflectColumnTitles, "No.", 1))
+	assert.Len(t, columns, 5)
+	assert.Equal(t, ColumnInfo{Title: "No.", Type: reflect.TypeFor[int]()}, columns[0])
+
+	columns = AnalyzeColumns(structType, NoColumnTitles())
+	assert.Len(t, columns, 5)
+	assert.Equal(t, ColumnInfo{Type: reflect.TypeFor[string]()}, columns[0])
+}
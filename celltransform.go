@@ -0,0 +1,40 @@
+package structtable
+
+import "reflect"
+
+// CellTransform returns a possibly replaced value for
+// the reflected value val of the column with index col.
+// It can be used to redact or transform values
+// before they are passed to a Renderer.
+// An invalid reflect.Value can be returned
+// to render the cell as null value.
+type CellTransform func(col int, val reflect.Value) reflect.Value
+
+// TransformCells returns a ColumnMapper that uses columnMapper
+// and applies transform to every column value of a row
+// reflected by the RowReflector of columnMapper.
+//
+// Because the transformation happens while reflecting the row,
+// it is applied before the renderer formats the values,
+// so type formatters like strfmt.FormatConfig.TypeFormatters
+// or excel.Renderer.TypeCellWriters see the transformed values.
+func TransformCells(columnMapper ColumnMapper, transform CellTransform) ColumnMapper {
+	return ColumnMapperFunc(func(structType reflect.Type) ([]string, RowReflector) {
+		titles, rowReflector := columnMapper.ColumnTitlesAndRowReflector(structType)
+		return titles, RowReflectorFunc(func(structValue reflect.Value) []reflect.Value {
+			columnValues := rowReflector.ReflectRow(structValue)
+			for col, val := range columnValues {
+				columnValues[col] = transform(col, val)
+			}
+			return columnValues
+		})
+	})
+}
+
+// RenderWithCellTransform renders like Render
+// but applies transform to every column value of the rows
+// before passing them to the renderer, see TransformCells.
+// The header row is not transformed.
+func RenderWithCellTransform(renderer Renderer, structSlice any, renderTitleRow bool, columnMapper ColumnMapper, transform CellTransform) error {
+	return Render(renderer, structSlice, renderTitleRow, TransformCells(columnMapper, transform))
+}
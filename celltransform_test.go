@@ -0,0 +1,26 @@
+package structtable
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderWithCellTransform(t *testing.T) {
+	rows := []renderTestRow{{A: "AT611904300234573201", B: "b1"}}
+	maskFirstColumn := func(col int, val reflect.Value) reflect.Value {
+		if col != 0 {
+			return val
+		}
+		str := val.String()
+		return reflect.ValueOf("****" + str[len(str)-4:])
+	}
+
+	r := new(recordingRenderer)
+	err := RenderWithCellTransform(r, rows, true, DefaultReflectColumnTitles, maskFirstColumn)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A", "Bee"}, r.header)
+	assert.Equal(t, [][]string{{"****3201", "b1"}}, r.rows)
+	assert.Equal(t, "AT611904300234573201", rows[0].A, "struct not modified")
+}
@@ -0,0 +1,47 @@
+package structtable
+
+import "github.com/domonda/go-errs"
+
+// RenderChunks renders the rows of structSlice in chunks
+// of up to rowsPerChunk rows, for example to split
+// a large export into multiple files.
+//
+// For every chunk beginChunk is called with the zero based
+// chunk index and returns the renderer for the chunk.
+// The renderer gets its own title row if renderTitleRow is true,
+// then the rows of the chunk are rendered and endChunk is called
+// with the chunk index and the renderer.
+// A single chunk is rendered for an empty structSlice.
+//
+// The row reflector of columnMapper is shared by all chunks,
+// so stateful reflectors like the one of WithRowNumberColumn
+// continue across chunks.
+func RenderChunks(structSlice any, renderTitleRow bool, columnMapper ColumnMapper, rowsPerChunk int, beginChunk func(chunk int) (Renderer, error), endChunk func(chunk int, renderer Renderer) error) error {
+	if rowsPerChunk <= 0 {
+		return errs.Errorf("rowsPerChunk must be positive, got %d", rowsPerChunk)
+	}
+	rows, err := ReflectRows(structSlice)
+	if err != nil {
+		return err
+	}
+
+	columnTitles, rowReflector := columnMapper.ColumnTitlesAndRowReflector(rows.Type().Elem())
+
+	for chunk, start := 0, 0; chunk == 0 || start < rows.Len(); chunk, start = chunk+1, start+rowsPerChunk {
+		renderer, err := beginChunk(chunk)
+		if err != nil {
+			return err
+		}
+		end := min(start+rowsPerChunk, rows.Len())
+		err = renderRows(renderer, columnTitles, renderTitleRow, reflectRows(rows, start, end, rowReflector), nil)
+		if err != nil {
+			return err
+		}
+		err = endChunk(chunk, renderer)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
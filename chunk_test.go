@@ -0,0 +1,45 @@
+package structtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderChunks(t *testing.T) {
+	rows := []renderTestRow{{"a1", "b1"}, {"a2", "b2"}, {"a3", "b3"}, {"a4", "b4"}, {"a5", "b5"}}
+
+	var chunks []*recordingRenderer
+	beginChunk := func(chunk int) (Renderer, error) {
+		assert.Equal(t, len(chunks), chunk, "chunk index")
+		chunks = append(chunks, new(recordingRenderer))
+		return chunks[chunk], nil
+	}
+	var ended []int
+	endChunk := func(chunk int, renderer Renderer) error {
+		assert.Same(t, chunks[chunk], renderer)
+		ended = append(ended, chunk)
+		return nil
+	}
+	err := RenderChunks(rows, true, DefaultReflectColumnTitles, 2, beginChunk, endChunk)
+	require.NoError(t, err)
+	require.Len(t, chunks, 3)
+	assert.Equal(t, []int{0, 1, 2}, ended)
+	for _, chunk := range chunks {
+		assert.Equal(t, []string{"A", "Bee"}, chunk.header)
+	}
+	assert.Equal(t, [][]string{{"a1", "b1"}, {"a2", "b2"}}, chunks[0].rows)
+	assert.Equal(t, [][]string{{"a3", "b3"}, {"a4", "b4"}}, chunks[1].rows)
+	assert.Equal(t, [][]string{{"a5", "b5"}}, chunks[2].rows)
+
+	chunks, ended = nil, nil
+	err = RenderChunks([]renderTestRow{}, true, DefaultReflectColumnTitles, 2, beginChunk, endChunk)
+	require.NoError(t, err)
+	require.Len(t, chunks, 1, "one chunk for empty slice")
+	assert.Equal(t, []string{"A", "Bee"}, chunks[0].header)
+	assert.Empty(t, chunks[0].rows)
+
+	err = RenderChunks(rows, true, DefaultReflectColumnTitles, 0, beginChunk, endChunk)
+	assert.Error(t, err, "zero rowsPerChunk")
+}
@@ -1,9 +1,11 @@
 package structtable
 
 import (
+	"cmp"
 	"fmt"
 	"go/token"
 	"reflect"
+	"slices"
 	"strings"
 	"unicode"
 )
@@ -83,10 +85,24 @@ type ReflectColumnTitles struct {
 	// return a column name in case the struct field has no tag named Tag.
 	// If UntaggedFieldTitle is nil, then the struct field name with be used unchanged.
 	UntaggedFieldTitle func(fieldName string) (columnTitle string)
+	// TitleFunc will be called with every struct field to return its column title.
+	// If TitleFunc is not nil, then it takes precedence over Tag and UntaggedFieldTitle.
+	// Useful for localized titles or titles depending on other struct field tags.
+	TitleFunc func(field reflect.StructField) (columnTitle string)
 	// MapIndices is a map from the index of a field in struct
 	// to the column index returned by ColumnTitlesAndRowReflector.
 	// If MapIndices is nil, then no mapping will be performed.
 	// Map to the index -1 to not create a column for a struct field.
+	//
+	// Mapped fields are placed at their column index first,
+	// then the unmapped fields fill the remaining columns
+	// in the order of the struct fields.
+	// If multiple fields are mapped to the same column index,
+	// then the field with the lowest index gets the column
+	// and the other fields are shifted to the following free columns,
+	// or to the last free column before it if there is no following one.
+	// Column indices beyond the number of columns
+	// are treated as the index of the last column.
 	MapIndices map[int]int
 }
 
@@ -102,6 +118,12 @@ func (n *ReflectColumnTitles) WithIgnoreTitle(ignoreTitle string) *ReflectColumn
 	return &mod
 }
 
+func (n *ReflectColumnTitles) WithTitleFunc(titleFunc func(field reflect.StructField) string) *ReflectColumnTitles {
+	mod := *n
+	mod.TitleFunc = titleFunc
+	return &mod
+}
+
 func (n *ReflectColumnTitles) WithMapIndex(fieldIndex, columnIndex int) *ReflectColumnTitles {
 	mod := *n
 	if mod.MapIndices == nil {
@@ -128,50 +150,89 @@ func (n *ReflectColumnTitles) WithMapIndices(mapIndices map[int]int) *ReflectCol
 
 func (n *ReflectColumnTitles) ColumnTitlesAndRowReflector(structType reflect.Type) (titles []string, rowReflector RowReflector) {
 	structFields := StructFieldTypes(structType)
-	indices := make([]int, len(structFields))
-
-	columnIndexUsed := make(map[int]bool)
-	getNextFreeColumnIndex := func() int {
-		for i := range structFields {
-			if !columnIndexUsed[i] {
-				return i
-			}
-		}
-		panic("getNextFreeColumnIndex should always find a free column index")
-	}
 
+	// Collect the struct fields that become columns
+	var (
+		fieldTitles  []string
+		fieldIndices []int // index in structFields
+		mapped       []int // index in fieldIndices of explicitly mapped fields
+	)
 	for i, structField := range structFields {
 		title := n.titleFromStructField(structField)
 		if title == n.IgnoreTitle {
-			indices[i] = -1
 			continue
 		}
-
-		index := getNextFreeColumnIndex()
-		if n.MapIndices != nil {
-			mappedIndex, ok := n.MapIndices[i]
-			if ok && !columnIndexUsed[mappedIndex] {
-				index = mappedIndex
+		if mappedIndex, ok := n.MapIndices[i]; ok {
+			if mappedIndex < 0 {
+				continue
 			}
+			mapped = append(mapped, len(fieldIndices))
 		}
-		if index < 0 || index >= len(structFields) {
-			indices[i] = -1
+		fieldTitles = append(fieldTitles, title)
+		fieldIndices = append(fieldIndices, i)
+	}
+	numCols := len(fieldIndices)
+
+	// columnFields[column] is the index in fieldIndices
+	columnFields := make([]int, numCols)
+	for column := range columnFields {
+		columnFields[column] = -1
+	}
+	// Place explicitly mapped fields first ordered by their mapped index,
+	// colliding fields by their field index
+	slices.SortStableFunc(mapped, func(a, b int) int {
+		return cmp.Compare(n.MapIndices[fieldIndices[a]], n.MapIndices[fieldIndices[b]])
+	})
+	for _, f := range mapped {
+		column := min(n.MapIndices[fieldIndices[f]], numCols-1)
+		for column < numCols && columnFields[column] != -1 {
+			column++
+		}
+		for column == numCols || columnFields[column] != -1 {
+			// No free column after the mapped index,
+			// use the last free column before it
+			column--
+		}
+		columnFields[column] = f
+	}
+	// Fill the remaining columns with the other fields in their order
+	isMapped := make([]bool, numCols)
+	for _, f := range mapped {
+		isMapped[f] = true
+	}
+	column := 0
+	for f := range fieldIndices {
+		if isMapped[f] {
 			continue
 		}
+		for columnFields[column] != -1 {
+			column++
+		}
+		columnFields[column] = f
+	}
 
-		indices[i] = index
-		columnIndexUsed[index] = true
-
-		titles = append(titles, title)
+	titles = make([]string, numCols)
+	for column, f := range columnFields {
+		titles[column] = fieldTitles[f]
+	}
+	if numCols == 0 {
+		titles = nil
 	}
 
+	// Resolve the index paths of the column fields once
+	// instead of walking the struct type for every row
+	structFieldIndices := StructFieldIndices(structType)
+	columnIndices := make([][]int, numCols)
+	for column, f := range columnFields {
+		columnIndices[column] = structFieldIndices[fieldIndices[f]]
+	}
 	rowReflector = RowReflectorFunc(func(structValue reflect.Value) []reflect.Value {
-		columnValues := make([]reflect.Value, len(titles))
-		structFields := StructFieldValues(structValue)
-		for i, index := range indices {
-			if index >= 0 && index < len(titles) {
-				columnValues[index] = structFields[i]
-			}
+		if structValue.Kind() == reflect.Ptr {
+			structValue = structValue.Elem()
+		}
+		columnValues := make([]reflect.Value, numCols)
+		for column, index := range columnIndices {
+			columnValues[column] = fieldByIndex(structValue, index)
 		}
 		return columnValues
 	})
@@ -180,6 +241,9 @@ func (n *ReflectColumnTitles) ColumnTitlesAndRowReflector(structType reflect.Typ
 }
 
 func (n *ReflectColumnTitles) titleFromStructField(structField reflect.StructField) string {
+	if n.TitleFunc != nil {
+		return n.TitleFunc(structField)
+	}
 	if tag, ok := structField.Tag.Lookup(n.Tag); ok {
 		if i := strings.IndexByte(tag, ','); i != -1 {
 			tag = tag[:i]
@@ -216,6 +280,41 @@ func StructFieldTypes(structType reflect.Type) (fields []reflect.StructField) {
 	return fields
 }
 
+// StructFieldIndices returns the index paths usable with
+// reflect.Value.FieldByIndex for the fields returned by
+// StructFieldTypes for structType in the same order.
+func StructFieldIndices(structType reflect.Type) (indices [][]int) {
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	for i := 0; i < structType.NumField(); i++ {
+		field := structType.Field(i)
+		switch {
+		case field.Anonymous:
+			for _, index := range StructFieldIndices(field.Type) {
+				indices = append(indices, append([]int{i}, index...))
+			}
+		case token.IsExported(field.Name):
+			indices = append(indices, []int{i})
+		}
+	}
+	return indices
+}
+
+// fieldByIndex returns the nested field of structValue
+// with the index path or the zero reflect.Value
+// if the path goes through a nil embedded pointer.
+func fieldByIndex(structValue reflect.Value, index []int) reflect.Value {
+	if len(index) == 1 {
+		return structValue.Field(index[0])
+	}
+	field, err := structValue.FieldByIndexErr(index)
+	if err != nil {
+		return reflect.Value{}
+	}
+	return field
+}
+
 // StructFieldValues returns the reflect.Value of exported struct fields
 // including the inlined fields of any anonymously embedded structs.
 func StructFieldValues(structValue reflect.Value) (values []reflect.Value) {
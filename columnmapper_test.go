@@ -2,7 +2,10 @@ package structtable
 
 import (
 	"reflect"
+	"strings"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 func TestReflectColumnTitles_ColumnTitlesAndRowReflector(t *testing.T) {
@@ -65,3 +68,145 @@ func TestSpacePascalCase(t *testing.T) {
 		})
 	}
 }
+
+func TestReflectColumnTitles_WithTitleFunc(t *testing.T) {
+	type row struct {
+		Name   string `col:"Name" i18n:"name"`
+		Amount float64
+		Skip   string `col:"-"`
+	}
+	catalog := map[string]string{"name": "Bezeichnung", "Amount": "Betrag", "Skip": "-"}
+	titleFunc := func(field reflect.StructField) string {
+		if key, ok := field.Tag.Lookup("i18n"); ok {
+			return catalog[key]
+		}
+		return catalog[field.Name]
+	}
+
+	titles, _ := DefaultReflectColumnTitles.WithTitleFunc(titleFunc).ColumnTitlesAndRowReflector(reflect.TypeOf(row{}))
+	if want := []string{"Bezeichnung", "Betrag"}; !reflect.DeepEqual(titles, want) {
+		t.Errorf("ReflectColumnTitles.ColumnTitlesAndRowReflector() titles = %v, want %v", titles, want)
+	}
+}
+
+func TestReorderColumns(t *testing.T) {
+	type row struct {
+		Name  string
+		City  string
+		Email string
+		Phone string
+	}
+	rows := []row{{"Alice", "Vienna", "alice@example.com", "123"}}
+
+	r := new(recordingRenderer)
+	err := Render(r, rows, true, ReorderColumns(DefaultReflectColumnTitles, "Email", "Name", "Unknown", "City"))
+	assert.NoError(t, err, "Render")
+	assert.Equal(t, []string{"Email", "Name", "City", "Phone"}, r.header)
+	assert.Equal(t, [][]string{{"alice@example.com", "Alice", "Vienna", "123"}}, r.rows)
+}
+
+func TestOverrideTitles(t *testing.T) {
+	type row struct {
+		Name    string
+		Ignored string `col:"-"`
+		City    string
+	}
+	rows := []row{{"Alice", "x", "Wien"}}
+	structType := reflect.TypeOf(row{})
+
+	mapper, err := OverrideTitles(ReorderColumns(DefaultReflectColumnTitles, "City"), structType, "Ort", "Name")
+	assert.NoError(t, err, "OverrideTitles")
+	r := new(recordingRenderer)
+	err = Render(r, rows, true, mapper)
+	assert.NoError(t, err, "Render")
+	assert.Equal(t, []string{"Ort", "Name"}, r.header)
+	assert.Equal(t, [][]string{{"Wien", "Alice"}}, r.rows)
+
+	_, err = OverrideTitles(DefaultReflectColumnTitles, structType, "Name", "Ignored", "City")
+	assert.Error(t, err, "more titles than columns")
+
+	titles, _ := mapper.ColumnTitlesAndRowReflector(reflect.TypeOf(struct{ A string }{}))
+	assert.Equal(t, []string{"A"}, titles, "titles of other struct types are not overridden")
+}
+
+func TestReflectColumnTitles_MapIndices(t *testing.T) {
+	type row struct {
+		A, B, C, D string
+	}
+	rows := []row{{"a", "b", "c", "d"}}
+	tests := []struct {
+		name       string
+		mapIndices map[int]int
+		want       []string
+	}{
+		{name: "no mapping", mapIndices: nil, want: []string{"A", "B", "C", "D"}},
+		{name: "move to front", mapIndices: map[int]int{3: 0}, want: []string{"D", "A", "B", "C"}},
+		{name: "swap", mapIndices: map[int]int{0: 1, 1: 0}, want: []string{"B", "A", "C", "D"}},
+		{name: "collision", mapIndices: map[int]int{0: 2, 1: 2}, want: []string{"C", "D", "A", "B"}},
+		{name: "collision at end", mapIndices: map[int]int{0: 3, 1: 3}, want: []string{"C", "D", "B", "A"}},
+		{name: "beyond field count", mapIndices: map[int]int{0: 10}, want: []string{"B", "C", "D", "A"}},
+		{name: "ignored", mapIndices: map[int]int{1: -1, 3: 0}, want: []string{"D", "A", "C"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := new(recordingRenderer)
+			err := Render(r, rows, true, DefaultReflectColumnTitles.WithMapIndices(tt.mapIndices))
+			assert.NoError(t, err, "Render")
+			assert.Equal(t, tt.want, r.header, "titles")
+			values := make([]string, len(tt.want))
+			for i, title := range tt.want {
+				values[i] = strings.ToLower(title)
+			}
+			assert.Equal(t, [][]string{values}, r.rows, "values match titles")
+		})
+	}
+}
+
+type embeddedInner struct {
+	C string
+	d string
+}
+
+type EmbeddedPtr struct {
+	E int
+}
+
+type embeddingRow struct {
+	A int
+	embeddedInner
+	*EmbeddedPtr
+	B string
+}
+
+func TestStructFieldIndices(t *testing.T) {
+	structType := reflect.TypeOf(embeddingRow{})
+	assert.Equal(t, [][]int{{0}, {1, 0}, {2, 0}, {3}}, StructFieldIndices(structType))
+	assert.Len(t, StructFieldIndices(structType), len(StructFieldTypes(structType)))
+
+	_, rowReflector := DefaultReflectColumnTitles.ColumnTitlesAndRowReflector(structType)
+	row := embeddingRow{A: 1, embeddedInner: embeddedInner{C: "c"}, EmbeddedPtr: &EmbeddedPtr{E: 2}, B: "b"}
+	values := rowReflector.ReflectRow(reflect.ValueOf(&row))
+	assert.Equal(t, []any{1, "c", 2, "b"}, []any{values[0].Interface(), values[1].Interface(), values[2].Interface(), values[3].Interface()})
+
+	row.EmbeddedPtr = nil
+	values = rowReflector.ReflectRow(reflect.ValueOf(row))
+	assert.False(t, values[2].IsValid(), "field of nil embedded pointer")
+}
+
+func BenchmarkRowReflector(b *testing.B) {
+	row := reflect.ValueOf(embeddingRow{A: 1, embeddedInner: embeddedInner{C: "c"}, EmbeddedPtr: &EmbeddedPtr{E: 2}, B: "b"})
+	b.Run("StructFieldValues", func(b *testing.B) {
+		b.ReportAllocs()
+		for range b.N {
+			StructFieldValues(row)
+		}
+	})
+	b.Run("ReflectColumnTitles", func(b *testing.B) {
+		_, rowReflector := DefaultReflectColumnTitles.ColumnTitlesAndRowReflector(row.Type())
+		b.ReportAllocs()
+		b.ResetTimer()
+		for range b.N {
+			rowReflector.ReflectRow(row)
+		}
+	})
+}
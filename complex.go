@@ -0,0 +1,101 @@
+package structtable
+
+import (
+	"encoding"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/domonda/go-types/strfmt"
+)
+
+// DefaultSliceJoiner is the usual separator
+// for the SliceJoiner options of the renderers.
+const DefaultSliceJoiner = ", "
+
+var (
+	stringerType      = reflect.TypeOf((*fmt.Stringer)(nil)).Elem()
+	textMarshalerType = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// ComplexValueFormatter formats values of types
+// without a text representation suitable for table cells,
+// see IsComplexType.
+type ComplexValueFormatter func(val reflect.Value) string
+
+// IsComplexType returns true if t or the type t points to
+// is a map, slice, array, chan, or func type that
+// neither implements fmt.Stringer nor encoding.TextMarshaler.
+// Byte slices are not complex because they are formatted as strings.
+func IsComplexType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Map, reflect.Array, reflect.Chan, reflect.Func:
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return false
+		}
+	default:
+		return false
+	}
+	for _, typ := range []reflect.Type{t, reflect.PointerTo(t)} {
+		if typ.Implements(stringerType) || typ.Implements(textMarshalerType) {
+			return false
+		}
+	}
+	return true
+}
+
+// FormatComplexValue is the default ComplexValueFormatter.
+// It formats maps, slices, and arrays as JSON
+// and chans and funcs as empty string.
+func FormatComplexValue(val reflect.Value) string {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		val = val.Elem()
+	}
+	switch val.Kind() {
+	case reflect.Chan, reflect.Func, reflect.Invalid:
+		return ""
+	}
+	b, err := json.Marshal(val.Interface())
+	if err != nil {
+		return fmt.Sprint(val.Interface())
+	}
+	return string(b)
+}
+
+// FormatJoinedSlice formats the elements of the slice or array val
+// with config and joins them with joiner, like "a, b, c" for
+// a []string{"a", "b", "c"} and DefaultSliceJoiner.
+// Null elements are formatted as config.Nil.
+// Returns false if val is not a slice or array
+// or if its elements are complex types themselves.
+func FormatJoinedSlice(val reflect.Value, joiner string, config *strfmt.FormatConfig) (string, bool) {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		val = val.Elem()
+	}
+	switch val.Kind() {
+	case reflect.Slice, reflect.Array:
+	default:
+		return "", false
+	}
+	if IsComplexType(val.Type().Elem()) {
+		return "", false
+	}
+	var b strings.Builder
+	for i := 0; i < val.Len(); i++ {
+		if i > 0 {
+			b.WriteString(joiner)
+		}
+		elem := val.Index(i)
+		if IsNull(elem) {
+			b.WriteString(config.Nil)
+			continue
+		}
+		b.WriteString(strfmt.FormatValue(elem, config))
+	}
+	return b.String(), true
+}
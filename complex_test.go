@@ -0,0 +1,94 @@
+package structtable
+
+import (
+	"net"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-types/strfmt"
+)
+
+type stringerSlice []int
+
+func (s stringerSlice) String() string { return "stringer" }
+
+func TestIsComplexType(t *testing.T) {
+	assert.True(t, IsComplexType(reflect.TypeOf(map[string]int{})))
+	assert.True(t, IsComplexType(reflect.TypeOf([]string{})))
+	assert.True(t, IsComplexType(reflect.TypeOf(&[]int{})))
+	assert.True(t, IsComplexType(reflect.TypeOf([2]int{})))
+	assert.True(t, IsComplexType(reflect.TypeOf(make(chan int))))
+	assert.True(t, IsComplexType(reflect.TypeOf(func() {})))
+	assert.False(t, IsComplexType(reflect.TypeOf([]byte{})), "byte slice")
+	assert.False(t, IsComplexType(reflect.TypeOf(stringerSlice{})), "fmt.Stringer")
+	assert.False(t, IsComplexType(reflect.TypeOf("")))
+	assert.False(t, IsComplexType(reflect.TypeOf(struct{}{})))
+}
+
+func TestTextRendererComplexValues(t *testing.T) {
+	type row struct {
+		Map   map[string]int
+		Slice []string
+		Func  func()
+		Any   any
+		IP    net.IP
+	}
+	rows := []row{{
+		Map:   map[string]int{"a": 1},
+		Slice: []string{"x", "y"},
+		Func:  func() {},
+		Any:   []int{1, 2},
+		IP:    net.IPv4(127, 0, 0, 1),
+	}}
+
+	txt := newJoinRenderer(strfmt.NewFormatConfig())
+	result, err := RenderBytes(txt, rows, false, DefaultReflectColumnTitles)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":1},x, y,,1, 2,127.0.0.1`+"\n", string(result), "slices joined by default")
+
+	txt = newJoinRenderer(strfmt.NewFormatConfig())
+	txt.SliceJoiner = ""
+	result, err = RenderBytes(txt, rows, false, DefaultReflectColumnTitles)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"a":1},["x","y"],,[1,2],127.0.0.1`+"\n", string(result))
+
+	txt = newJoinRenderer(strfmt.NewFormatConfig())
+	txt.SliceJoiner = ""
+	txt.ComplexValueFormatter = func(val reflect.Value) string { return "complex" }
+	result, err = RenderBytes(txt, rows, false, DefaultReflectColumnTitles)
+	assert.NoError(t, err)
+	assert.Equal(t, "complex,complex,complex,complex,127.0.0.1\n", string(result))
+}
+
+func TestTextRendererSliceJoiner(t *testing.T) {
+	type row struct {
+		Tags    []string
+		Numbers [3]float64
+		Ptrs    []*int
+		Nested  []map[string]int
+	}
+	one := 1
+	rows := []row{{
+		Tags:    []string{"a", "b", "c"},
+		Numbers: [3]float64{1.5, 2, 1000},
+		Ptrs:    []*int{&one, nil},
+		Nested:  []map[string]int{{"x": 1}},
+	}}
+
+	config := strfmt.NewEnglishFormatConfig()
+	config.Nil = "-"
+	txt := newJoinRenderer(config)
+	txt.SliceJoiner = DefaultSliceJoiner
+	result, err := RenderBytes(txt, rows, false, DefaultReflectColumnTitles)
+	assert.NoError(t, err)
+	expected := strings.Join([]string{
+		"a, b, c",
+		strfmt.FormatValue(reflect.ValueOf(1.5), config) + ", " + strfmt.FormatValue(reflect.ValueOf(2.0), config) + ", " + strfmt.FormatValue(reflect.ValueOf(1000.0), config),
+		"1, -",
+		`[{"x":1}]`,
+	}, ",") + "\n"
+	assert.Equal(t, expected, string(result))
+}
@@ -0,0 +1,42 @@
+package csv
+
+import (
+	"fmt"
+
+	"github.com/ungerik/go-fs"
+
+	"github.com/domonda/go-structtable"
+	"github.com/domonda/go-types/strfmt"
+)
+
+// RenderChunkedFiles renders the rows of structSlice into files
+// of up to rowsPerFile data rows each in the directory dir
+// named baseName_001.csv, baseName_002.csv, and so on,
+// for downstream tools with file size limits.
+// Every file gets its own title row if renderTitleRow is true.
+// An optional config is used for formatting the values,
+// else strfmt.NewFormatConfig.
+// The created files are returned in order, also in case of an error.
+func RenderChunkedFiles(dir fs.File, baseName string, rowsPerFile int, structSlice any, renderTitleRow bool, mapper structtable.ColumnMapper, config ...*strfmt.FormatConfig) (files []fs.File, err error) {
+	formatConfig := strfmt.NewFormatConfig()
+	if len(config) > 0 && config[0] != nil {
+		formatConfig = config[0]
+	}
+	renderer := NewRenderer(formatConfig)
+
+	beginChunk := func(chunk int) (structtable.Renderer, error) {
+		renderer.Reset()
+		return renderer, nil
+	}
+	endChunk := func(chunk int, _ structtable.Renderer) error {
+		file := dir.Join(fmt.Sprintf("%s_%03d.csv", baseName, chunk+1))
+		err := renderer.WriteResultFile(file)
+		if err != nil {
+			return err
+		}
+		files = append(files, file)
+		return nil
+	}
+	err = structtable.RenderChunks(structSlice, renderTitleRow, mapper, rowsPerFile, beginChunk, endChunk)
+	return files, err
+}
@@ -0,0 +1,39 @@
+package csv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/ungerik/go-fs"
+
+	"github.com/domonda/go-structtable"
+)
+
+func TestRenderChunkedFiles(t *testing.T) {
+	type row struct {
+		Name  string
+		Count int
+	}
+	rows := []row{{"a", 1}, {"b", 2}, {"c", 3}, {"d", 4}, {"e", 5}}
+	dir := fs.File(t.TempDir())
+
+	files, err := RenderChunkedFiles(dir, "export", 2, rows, true, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err)
+	require.Len(t, files, 3)
+
+	expected := []struct {
+		name string
+		data string
+	}{
+		{"export_001.csv", "\uFEFFName;Count\r\na;1\r\nb;2\r\n"},
+		{"export_002.csv", "\uFEFFName;Count\r\nc;3\r\nd;4\r\n"},
+		{"export_003.csv", "\uFEFFName;Count\r\ne;5\r\n"},
+	}
+	for i, exp := range expected {
+		assert.Equal(t, exp.name, files[i].Name())
+		data, err := files[i].ReadAllString()
+		require.NoError(t, err)
+		assert.Equal(t, exp.data, data, exp.name)
+	}
+}
@@ -1,13 +1,19 @@
 package csv
 
 import (
+	"reflect"
 	"strconv"
 	"strings"
+	"time"
 
+	"github.com/domonda/go-errs"
 	"github.com/domonda/go-types/bank"
 	"github.com/domonda/go-types/date"
 	"github.com/domonda/go-types/float"
 	"github.com/domonda/go-types/money"
+	"github.com/domonda/go-types/nullable"
+
+	"github.com/domonda/go-structtable"
 )
 
 type DataType string
@@ -93,3 +99,76 @@ func StringDataTypes(str string) []DataType {
 	}
 	return types
 }
+
+// dataTypes maps types to their DataType,
+// other types are detected by their kind
+var dataTypes = map[reflect.Type]DataType{
+	reflect.TypeOf(nullable.NonEmptyString("")): DataTypeNullableString,
+	reflect.TypeOf(money.Amount(0)):             DataTypeMoneyAmount,
+	reflect.TypeOf(money.Currency("")):          DataTypeCurrency,
+	reflect.TypeOf(money.NullableCurrency("")):  DataTypeNullableCurrency,
+	reflect.TypeOf(date.Date("")):               DataTypeDate,
+	reflect.TypeOf(date.NullableDate("")):       DataTypeNullableDate,
+	reflect.TypeOf(time.Time{}):                 DataTypeTime,
+	reflect.TypeOf(nullable.Time{}):             DataTypeNullableTime,
+	reflect.TypeOf(bank.IBAN("")):               DataTypeIBAN,
+	reflect.TypeOf(bank.NullableIBAN("")):       DataTypeNullableIBAN,
+	reflect.TypeOf(bank.BIC("")):                DataTypeBIC,
+	reflect.TypeOf(bank.NullableBIC("")):        DataTypeNullableBIC,
+}
+
+// DataTypeOf returns the DataType for values of type t.
+// Pointer types return the nullable DataType of the type they point to.
+// Types without a more specific DataType return DataTypeString.
+func DataTypeOf(t reflect.Type) DataType {
+	if t.Kind() == reflect.Ptr {
+		dataType := DataTypeOf(t.Elem())
+		if !dataType.Nullable() {
+			dataType = "NULL_" + dataType
+		}
+		return dataType
+	}
+	if dataType, ok := dataTypes[t]; ok {
+		return dataType
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return DataTypeInt
+	case reflect.Float32, reflect.Float64:
+		return DataTypeFloat
+	}
+	return DataTypeString
+}
+
+// DataTypesOf returns the DataType of every column
+// that columnMapper maps from structType.
+func DataTypesOf(structType reflect.Type, columnMapper structtable.ColumnMapper) []DataType {
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	_, rowReflector := columnMapper.ColumnTitlesAndRowReflector(structType)
+	columnValues := rowReflector.ReflectRow(reflect.New(structType).Elem())
+	types := make([]DataType, len(columnValues))
+	for i, val := range columnValues {
+		types[i] = DataTypeString
+		if val.IsValid() {
+			types[i] = DataTypeOf(val.Type())
+		}
+	}
+	return types
+}
+
+// ParseDataTypes returns the DataType of every string of row,
+// for example of a type header row written by
+// a Renderer with WithTypeHeader.
+func ParseDataTypes(row []string) ([]DataType, error) {
+	types := make([]DataType, len(row))
+	for i, str := range row {
+		types[i] = DataType(str)
+		if !types[i].Valid() {
+			return nil, errs.Errorf("invalid data type %q in column %d", str, i)
+		}
+	}
+	return types, nil
+}
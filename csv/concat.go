@@ -0,0 +1,97 @@
+package csv
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/domonda/go-types/charset"
+)
+
+// Concat writes the UTF-8 encoded CSV parts to dst
+// as a single CSV keeping only the header of the first part,
+// for example to join the shards of an export.
+//
+// The first part is written verbatim.
+// From every following part the UTF-8 BOM, a "sep=" line,
+// and the header line are removed before it is appended.
+// The header line ends at the first newline that is not
+// within a quoted field, so titles may contain line breaks.
+// If a written part does not end with a newline,
+// then the newline of its header line is written before the next part.
+//
+// Use ConcatHeaderLines for parts with more lines before
+// the data rows, like comment lines or a type header.
+func Concat(dst io.Writer, parts ...[]byte) error {
+	return ConcatHeaderLines(dst, 1, parts...)
+}
+
+// ConcatHeaderLines works like Concat but removes headerLines
+// lines after the UTF-8 BOM and a "sep=" line from every part
+// after the first instead of only the header line.
+// Renderer.NumHeaderLines returns the number of lines
+// written before the data rows of a rendered part.
+func ConcatHeaderLines(dst io.Writer, headerLines int, parts ...[]byte) error {
+	var newline []byte
+	for i, part := range parts {
+		if i > 0 {
+			part = bytes.TrimPrefix(part, []byte(charset.BOMUTF8))
+			line, rest := cutLine(part)
+			if parseSepHeaderLine(bytes.TrimRight(line, "\r\n")) == "" {
+				rest = part
+			}
+			for range headerLines {
+				_, rest = cutLine(rest)
+			}
+			part = rest
+		}
+		if len(part) == 0 {
+			continue
+		}
+		if len(newline) > 0 {
+			_, err := dst.Write(newline)
+			if err != nil {
+				return err
+			}
+		}
+		_, err := dst.Write(part)
+		if err != nil {
+			return err
+		}
+		newline = nil
+		if !bytes.HasSuffix(part, []byte{'\n'}) {
+			newline = lineNewline(parts[i])
+		}
+	}
+	return nil
+}
+
+// cutLine returns the first line of data including its newline
+// and the rest of data. Newlines within double quoted
+// fields don't end the line.
+func cutLine(data []byte) (line, rest []byte) {
+	inQuotes := false
+	for i, c := range data {
+		switch c {
+		case '"':
+			inQuotes = !inQuotes
+		case '\n':
+			if !inQuotes {
+				return data[:i+1], data[i+1:]
+			}
+		}
+	}
+	return data, nil
+}
+
+// lineNewline returns the newline of the first line of data
+// or "\r\n" if data has only one line.
+func lineNewline(data []byte) []byte {
+	line, _ := cutLine(data)
+	switch {
+	case bytes.HasSuffix(line, []byte("\r\n")):
+		return []byte("\r\n")
+	case bytes.HasSuffix(line, []byte("\n")):
+		return []byte("\n")
+	}
+	return []byte("\r\n")
+}
@@ -0,0 +1,69 @@
+package csv
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-structtable"
+	"github.com/domonda/go-types/charset"
+	"github.com/domonda/go-types/strfmt"
+)
+
+func TestConcat(t *testing.T) {
+	type row struct {
+		Name  string `col:"Full\nName"`
+		Count int
+	}
+	render := func(rows []row, trailingNewline bool) []byte {
+		renderer := NewRenderer(strfmt.NewFormatConfig()).WithTrailingNewline(trailingNewline)
+		result, err := structtable.RenderBytes(renderer, rows, true, structtable.DefaultReflectColumnTitles)
+		assert.NoError(t, err, "RenderBytes")
+		return result
+	}
+
+	var buf bytes.Buffer
+	err := Concat(&buf,
+		render([]row{{"A", 1}}, false),
+		render(nil, true),
+		render([]row{{"B", 2}, {"C", 3}}, true),
+	)
+	assert.NoError(t, err)
+	const expected = "\"Full\nName\";Count\r\nA;1\r\nB;2\r\nC;3\r\n"
+	assert.Equal(t, string(charset.BOMUTF8)+expected, buf.String())
+
+	buf.Reset()
+	err = Concat(&buf, []byte("sep=,\nA,B\n1,2\n"), []byte("sep=,\nA,B\n3,4"), []byte("A,B\n5,6\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "sep=,\nA,B\n1,2\n3,4\n5,6\n", buf.String())
+}
+
+func TestConcatHeaderLines(t *testing.T) {
+	type row struct {
+		Name   string
+		Amount int
+	}
+	newRenderer := func() *Renderer {
+		return NewRenderer(strfmt.NewFormatConfig()).
+			WithBOM(false).
+			WithCommentLines([]string{" shard"}).
+			WithHeaderComment("Export v1").
+			WithTypeHeader([]DataType{DataTypeString, DataTypeInt})
+	}
+	render := func(rows []row) []byte {
+		result, err := structtable.RenderBytes(newRenderer(), rows, true, structtable.DefaultReflectColumnTitles)
+		assert.NoError(t, err, "RenderBytes")
+		return result
+	}
+
+	assert.Equal(t, 4, newRenderer().NumHeaderLines())
+
+	var buf bytes.Buffer
+	err := ConcatHeaderLines(&buf, newRenderer().NumHeaderLines(), render([]row{{"a", 1}}), render([]row{{"b", 2}}))
+	assert.NoError(t, err)
+	assert.Equal(t, "# shard\r\nExport v1\r\nName;Amount\r\nSTRING;INT\r\na;1\r\nb;2\r\n", buf.String())
+
+	renderer := NewRenderer(strfmt.NewFormatConfig()).WithHeaderComment("Line 1\r\nLine 2\r\n")
+	assert.Equal(t, 3, renderer.NumHeaderLines(), "multi-line header comment")
+}
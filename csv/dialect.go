@@ -0,0 +1,70 @@
+package csv
+
+import (
+	"github.com/domonda/go-types/strfmt"
+)
+
+// NewExcelEURenderer returns a Renderer for CSV files
+// that can be opened by Excel with European regional settings
+// like German: ";" as delimiter, "\r\n" newlines, and a UTF-8 BOM.
+// If config is nil, then strfmt.NewGermanFormatConfig is used
+// so that numbers are formatted with decimal commas
+// as expected by Excel with those settings.
+func NewExcelEURenderer(config *strfmt.FormatConfig) *Renderer {
+	if config == nil {
+		config = strfmt.NewGermanFormatConfig()
+	}
+	return NewRenderer(config).
+		WithDelimiter(";").
+		WithNewline("\r\n").
+		WithBOM(true)
+}
+
+// NewExcelUSRenderer returns a Renderer for CSV files
+// that can be opened by Excel with US regional settings:
+// "," as delimiter, "\r\n" newlines, and a UTF-8 BOM.
+// If config is nil, then strfmt.NewFormatConfig is used
+// which formats numbers with decimal points.
+func NewExcelUSRenderer(config *strfmt.FormatConfig) *Renderer {
+	if config == nil {
+		config = strfmt.NewFormatConfig()
+	}
+	return NewRenderer(config).
+		WithDelimiter(",").
+		WithNewline("\r\n").
+		WithBOM(true)
+}
+
+// NewRFC4180Renderer returns a Renderer for strict CSV
+// as specified by RFC 4180: "," as delimiter, "\r\n" newlines,
+// no BOM, and only fields containing delimiters,
+// quotes, or line breaks are quoted.
+// If config is nil, then strfmt.NewFormatConfig is used.
+func NewRFC4180Renderer(config *strfmt.FormatConfig) *Renderer {
+	if config == nil {
+		config = strfmt.NewFormatConfig()
+	}
+	return NewRenderer(config).
+		WithDelimiter(",").
+		WithNewline("\r\n").
+		WithBOM(false).
+		WithQuoteAllFields(false).
+		WithQuoteEmptyFields(false)
+}
+
+// NewUnixRenderer returns a Renderer for CSV files
+// processed by Unix tools like awk or grep:
+// "," as delimiter, "\n" newlines, no BOM, and only fields
+// containing delimiters, quotes, or line breaks are quoted.
+// If config is nil, then strfmt.NewFormatConfig is used.
+func NewUnixRenderer(config *strfmt.FormatConfig) *Renderer {
+	if config == nil {
+		config = strfmt.NewFormatConfig()
+	}
+	return NewRenderer(config).
+		WithDelimiter(",").
+		WithNewline("\n").
+		WithBOM(false).
+		WithQuoteAllFields(false).
+		WithQuoteEmptyFields(false)
+}
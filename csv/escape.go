@@ -0,0 +1,121 @@
+package csv
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// EscapeStyle defines how quote characters within fields are escaped
+type EscapeStyle int
+
+const (
+	// DoubleQuote escapes quotes by doubling them
+	// like `"Say ""Hello"""` as specified by RFC 4180.
+	DoubleQuote EscapeStyle = iota
+	// Backslash escapes quotes and backslashes with a backslash
+	// like `"Say \"Hello\""` as used by MySQL and similar systems.
+	// The parser also unescapes `\n`, `\r`, `\t`, and `\0`.
+	Backslash
+)
+
+func (s EscapeStyle) String() string {
+	switch s {
+	case DoubleQuote:
+		return "DoubleQuote"
+	case Backslash:
+		return "Backslash"
+	default:
+		return fmt.Sprintf("EscapeStyle(%d)", int(s))
+	}
+}
+
+// Valid returns true if s is a known EscapeStyle
+func (s EscapeStyle) Valid() bool {
+	return s == DoubleQuote || s == Backslash
+}
+
+var backslashEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// escapeField escapes the quotes within field according to style
+func escapeField(field string, style EscapeStyle) []byte {
+	if style == Backslash {
+		return []byte(backslashEscaper.Replace(field))
+	}
+	return bytes.ReplaceAll([]byte(field), doubleQuote, doubleDoubleQuote)
+}
+
+// readLinesBackslash splits lines into rows of fields where quotes
+// and backslashes within fields are escaped with a backslash.
+// Unlike readLines for doubled quotes, escaped quotes can be detected
+// unambiguously, so the lines are parsed as a stream of characters
+// where quoted fields may span multiple lines that are joined
+// with newlineReplacement.
+// Runs of separators are treated as one if collapseDelimiters is true.
+// Like readLines, the returned rows have the indices of their first line
+// and empty or joined lines result in nil rows.
+func readLinesBackslash(lines [][]byte, separator []byte, quote byte, collapseDelimiters bool, newlineReplacement string) [][]string {
+	rows := make([][]string, len(lines))
+	for lineIndex := 0; lineIndex < len(lines); lineIndex++ {
+		line := lines[lineIndex]
+		if len(line) == 0 {
+			continue
+		}
+		var (
+			row      []string
+			field    []byte
+			inQuotes bool
+			rowIndex = lineIndex
+		)
+		for i := 0; ; i++ {
+			if i == len(line) {
+				if inQuotes && lineIndex+1 < len(lines) {
+					// Line break within quoted field
+					field = append(field, newlineReplacement...)
+					lineIndex++
+					line = lines[lineIndex]
+					i = -1
+					continue
+				}
+				row = append(row, string(field))
+				break
+			}
+			c := line[i]
+			switch {
+			case c == '\\' && i+1 < len(line):
+				i++
+				field = append(field, unescapeBackslash(line[i]))
+			case c == quote && !inQuotes && len(field) == 0:
+				inQuotes = true
+			case c == quote && inQuotes:
+				inQuotes = false
+			case !inQuotes && bytes.HasPrefix(line[i:], separator):
+				row = append(row, string(field))
+				field = nil
+				i += len(separator) - 1
+				for collapseDelimiters && bytes.HasPrefix(line[i+1:], separator) {
+					i += len(separator)
+				}
+			default:
+				field = append(field, c)
+			}
+		}
+		rows[rowIndex] = row
+	}
+	return rows
+}
+
+func unescapeBackslash(c byte) byte {
+	switch c {
+	case 'n':
+		return '\n'
+	case 'r':
+		return '\r'
+	case 't':
+		return '\t'
+	case '0':
+		return 0
+	default:
+		return c
+	}
+}
@@ -0,0 +1,59 @@
+package csv
+
+// IsExcelNumberMangled returns true if field consists only of digits
+// that Excel would change when interpreting them as number:
+// more than 15 digits that are beyond the precision of Excel numbers
+// like credit card numbers, or a leading zero like zip codes.
+func IsExcelNumberMangled(field string) bool {
+	if len(field) < 2 {
+		return false
+	}
+	for i := 0; i < len(field); i++ {
+		if field[i] < '0' || field[i] > '9' {
+			return false
+		}
+	}
+	return field[0] == '0' || len(field) > 15
+}
+
+// WithExcelText protects data fields for which IsExcelNumberMangled
+// returns true from being interpreted as numbers by Excel.
+// The fields are quoted and prefixed with prefix,
+// where the prefix "=" renders the field as Excel text formula ="field",
+// and other prefixes like "\t" are prepended as is.
+// An empty prefix only quotes the fields which
+// is not respected by all Excel versions.
+// The protection applies to the columns with the passed indices
+// or to all columns if no indices are passed.
+func (csv *Renderer) WithExcelText(prefix string, cols ...int) *Renderer {
+	if len(cols) == 0 {
+		csv.excelTextAll = &prefix
+		return csv
+	}
+	if csv.excelText == nil {
+		csv.excelText = make(map[int]string)
+	}
+	for _, col := range cols {
+		csv.excelText[col] = prefix
+	}
+	return csv
+}
+
+// excelTextField returns the field protected for Excel
+// and true if it has to be quoted because of the protection.
+func (csv *Renderer) excelTextField(col int, field string) (string, bool) {
+	prefix, ok := csv.excelText[col]
+	if !ok {
+		if csv.excelTextAll == nil {
+			return field, false
+		}
+		prefix = *csv.excelTextAll
+	}
+	if !IsExcelNumberMangled(field) {
+		return field, false
+	}
+	if prefix == "=" {
+		return `="` + field + `"`, true
+	}
+	return prefix + field, true
+}
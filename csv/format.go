@@ -3,12 +3,33 @@ package csv
 import (
 	"errors"
 	"fmt"
+	"slices"
 )
 
+// DefaultQuote is the quote character used
+// if Format.Quote is empty.
+const DefaultQuote = `"`
+
 type Format struct {
 	Encoding  string `json:"encoding"`
 	Separator string `json:"separator"`
 	Newline   string `json:"newline"`
+	// Quote is the character used for quoting fields.
+	// An empty string means DefaultQuote.
+	Quote string `json:"quote,omitempty"`
+	// Escape is the style of escaping quotes within fields,
+	// the zero value DoubleQuote is the RFC 4180 style.
+	Escape EscapeStyle `json:"escape,omitempty"`
+	// CollapseDelimiters makes the parser treat runs of consecutive
+	// separators outside of quoted fields as a single separator,
+	// for example for whitespace aligned files.
+	// Off by default because empty fields can't be parsed with it.
+	CollapseDelimiters bool `json:"collapseDelimiters,omitempty"`
+	// CommentPrefix is an optional prefix of comment lines.
+	// Lines beginning with CommentPrefix after optional whitespace
+	// will be skipped by the parser and comment lines
+	// rendered with this prefix.
+	CommentPrefix string `json:"commentPrefix,omitempty"`
 }
 
 // NewFormat returns a Format with the passed separator,
@@ -37,13 +58,77 @@ func (f *Format) Validate() error {
 		return errors.New("missing csv.Format.Newline")
 	case f.Newline != "\n" && f.Newline != "\n\r" && f.Newline != "\r\n":
 		return fmt.Errorf("invalid csv.Format.Newline: %q", f.Newline)
+	case len(f.Quote) > 1:
+		return fmt.Errorf("invalid csv.Format.Quote: %q", f.Quote)
+	case !f.Escape.Valid():
+		return fmt.Errorf("invalid csv.Format.Escape: %s", f.Escape)
 	}
 	return nil
 }
 
+// QuoteChar returns the first byte of Quote
+// or the double quote character if Quote is empty.
+func (f *Format) QuoteChar() byte {
+	if f.Quote == "" {
+		return DefaultQuote[0]
+	}
+	return f.Quote[0]
+}
+
 type FormatDetectionConfig struct {
 	Encodings     []string `json:"encodings"`
 	EncodingTests []string `json:"encodingTests"`
+	// Quotes are candidate quote characters in addition
+	// to DefaultQuote that are detected by counting
+	// the fields beginning and ending with them.
+	// Empty by default, add "'" to detect single quotes,
+	// which is opt-in because apostrophes at the beginning
+	// and end of unquoted fields would be misdetected.
+	Quotes []string `json:"quotes,omitempty"`
+	// CommentPrefix is an optional prefix of comment lines
+	// that will be skipped before detecting the format.
+	CommentPrefix string `json:"commentPrefix,omitempty"`
+}
+
+// FormatDetectionResult is the result of the format detection
+// with statistics that can be used to decide if the
+// detected Format should be confirmed by a user.
+type FormatDetectionResult struct {
+	Format *Format `json:"format"`
+	// SeparatorCounts are the counts of the separator
+	// candidates in all lines
+	SeparatorCounts SeparatorCounts `json:"separatorCounts"`
+	// NumNonEmptyLines is the number of non empty lines
+	// after removing comment lines
+	NumNonEmptyLines int `json:"numNonEmptyLines"`
+	// SeparatorHeader is true if the separator
+	// was read from a "sep=" header line
+	SeparatorHeader bool `json:"separatorHeader,omitempty"`
+	// Confidence of the detected separator between 0 and 1.
+	// It is 1 if the separator was read from a header line
+	// or if only one separator candidate was found,
+	// and 0 if no separator was found or if the most common
+	// candidates have the same count.
+	// Else it is 1 minus the ratio of the count
+	// of the runner-up to the count of the dominant separator.
+	Confidence float64 `json:"confidence"`
+}
+
+// SeparatorCounts holds the counts of separator candidates
+type SeparatorCounts struct {
+	Commas     int `json:"commas"`
+	Semicolons int `json:"semicolons"`
+	Tabs       int `json:"tabs"`
+}
+
+func (c *SeparatorCounts) confidence() float64 {
+	counts := []int{c.Commas, c.Semicolons, c.Tabs}
+	slices.Sort(counts)
+	dominant, runnerUp := counts[2], counts[1]
+	if dominant == 0 {
+		return 0
+	}
+	return 1 - float64(runnerUp)/float64(dominant)
 }
 
 func NewFormatDetectionConfig() *FormatDetectionConfig {
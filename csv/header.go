@@ -0,0 +1,71 @@
+package csv
+
+// maxHeaderDetectionRows is the maximum number of data rows
+// that DetectHeaderRow compares against the first row.
+const maxHeaderDetectionRows = 100
+
+// DetectHeaderRow uses a heuristic to detect if the first of the
+// passed rows is a header row by comparing the data types
+// of its fields (see StringDataTypes) with the data types
+// of the fields in the following rows.
+//
+// The first row is detected as header if none of its fields
+// can be parsed as any data type other than string
+// while at least one column of the following rows
+// has a data type like a number or date that all its
+// non empty fields share.
+// If all columns contain only strings or if there are
+// less than two rows, then no header can be detected
+// and false is returned.
+func DetectHeaderRow(rows [][]string) bool {
+	if len(rows) < 2 || len(rows[0]) == 0 {
+		return false
+	}
+	for _, field := range rows[0] {
+		if len(StringDataTypes(field)) > 0 {
+			return false
+		}
+	}
+	dataRows := rows[1:min(len(rows), maxHeaderDetectionRows+1)]
+	for col := range rows[0] {
+		if len(commonColumnDataTypes(dataRows, col)) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// commonColumnDataTypes returns the data types
+// shared by all non empty fields of a column
+// or nil if the column has no non empty fields.
+func commonColumnDataTypes(rows [][]string, col int) (common []DataType) {
+	hasField := false
+	for _, row := range rows {
+		if col >= len(row) || row[col] == "" {
+			continue
+		}
+		types := StringDataTypes(row[col])
+		if !hasField {
+			common = types
+			hasField = true
+			continue
+		}
+		common = intersectDataTypes(common, types)
+		if len(common) == 0 {
+			return nil
+		}
+	}
+	return common
+}
+
+func intersectDataTypes(a, b []DataType) (result []DataType) {
+	for _, t := range a {
+		for _, u := range b {
+			if t == u {
+				result = append(result, t)
+				break
+			}
+		}
+	}
+	return result
+}
@@ -0,0 +1,89 @@
+package csv
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-structtable"
+)
+
+func TestDetectHeaderRow(t *testing.T) {
+	tests := []struct {
+		name string
+		rows [][]string
+		want bool
+	}{
+		{
+			name: "header",
+			rows: [][]string{
+				{"Name", "Count", "Date"},
+				{"Apple", "3", "2024-01-02"},
+				{"Kiwi", "12", "2024-02-03"},
+			},
+			want: true,
+		},
+		{
+			name: "header with empty fields",
+			rows: [][]string{
+				{"Name", "", "Amount"},
+				{"Apple", "", "1.5"},
+				{"Kiwi", "x", ""},
+			},
+			want: true,
+		},
+		{
+			name: "headerless",
+			rows: [][]string{
+				{"Apple", "3", "2024-01-02"},
+				{"Kiwi", "12", "2024-02-03"},
+			},
+			want: false,
+		},
+		{
+			name: "only strings",
+			rows: [][]string{
+				{"Name", "Color"},
+				{"Apple", "Red"},
+				{"Kiwi", "Green"},
+			},
+			want: false,
+		},
+		{
+			name: "single row",
+			rows: [][]string{{"Name", "Count"}},
+			want: false,
+		},
+		{
+			name: "no rows",
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, DetectHeaderRow(tt.rows))
+		})
+	}
+}
+
+func TestReadAutoDetectHeader(t *testing.T) {
+	type row struct {
+		Name  string
+		Count int
+	}
+	columns := []ColumnMapping{{Index: 0, StructField: "Name"}, {Index: 1, StructField: "Count"}}
+
+	for _, rows := range [][][]string{
+		{{"Name", "Count"}, {"Apple", "3"}, {"Kiwi", "12"}},
+		{{"Apple", "3"}, {"Kiwi", "12"}},
+	} {
+		reader, err := NewReaderFromRows(rows, NewFormat(","), "", nil, columns)
+		assert.NoError(t, err, "NewReaderFromRows")
+		reader.AutoDetectHeader = true
+
+		var result []row
+		_, err = structtable.Read(reader, &result, 0)
+		assert.NoError(t, err, "Read")
+		assert.Equal(t, []row{{"Apple", 3}, {"Kiwi", 12}}, result)
+	}
+}
@@ -0,0 +1,85 @@
+package csv
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/domonda/go-errs"
+
+	"github.com/domonda/go-structtable"
+)
+
+// MappingFromStruct returns the ColumnMapping for every exported field
+// of structType whose title matches a column title of header,
+// so that reading uses the same struct tags as rendering
+// with structtable.ReflectColumnTitles.
+//
+// The title of a field is the value of its struct tag named tag
+// up to an optional comma. Untagged fields match a column title
+// equal to the field name or to structtable.SpacePascalCase of the field name.
+// Fields with the tag value "-" are ignored.
+// An error is returned if a field with the tag option "required"
+// like `col:"Amount,required"` has no matching column title.
+func MappingFromStruct(structType reflect.Type, header []string, tag string) ([]ColumnMapping, error) {
+	return mappingFromStruct(structType, header, tag, false)
+}
+
+// MappingFromStructIgnoreCase works like MappingFromStruct
+// but matches titles case-insensitively.
+func MappingFromStructIgnoreCase(structType reflect.Type, header []string, tag string) ([]ColumnMapping, error) {
+	return mappingFromStruct(structType, header, tag, true)
+}
+
+func mappingFromStruct(structType reflect.Type, header []string, tag string, ignoreCase bool) (mapping []ColumnMapping, err error) {
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	if structType.Kind() != reflect.Struct {
+		return nil, errs.Errorf("expected struct type, but got %s", structType)
+	}
+
+	equal := func(a, b string) bool {
+		if ignoreCase {
+			return strings.EqualFold(a, b)
+		}
+		return a == b
+	}
+
+	for _, field := range structtable.StructFieldTypes(structType) {
+		titles := []string{field.Name, structtable.SpacePascalCase(field.Name)}
+		required := false
+		if tagValue, ok := field.Tag.Lookup(tag); ok {
+			title, options, _ := strings.Cut(tagValue, ",")
+			if title == "-" {
+				continue
+			}
+			if title != "" {
+				titles = []string{title}
+			}
+			for _, option := range strings.Split(options, ",") {
+				if option == "required" {
+					required = true
+				}
+			}
+		}
+
+		index := -1
+	findIndex:
+		for i, column := range header {
+			for _, title := range titles {
+				if equal(strings.TrimSpace(column), title) {
+					index = i
+					break findIndex
+				}
+			}
+		}
+		if index == -1 {
+			if required {
+				return nil, errs.Errorf("no column for required struct field %s with title %q", field.Name, titles[0])
+			}
+			continue
+		}
+		mapping = append(mapping, ColumnMapping{Index: index, StructField: field.Name})
+	}
+	return mapping, nil
+}
@@ -0,0 +1,56 @@
+package csv
+
+import (
+	"reflect"
+	"strings"
+)
+
+// HeaderRowsSeparator separates the category and field titles
+// of the column titles returned by CombineHeaderRows.
+const HeaderRowsSeparator = " / "
+
+// CombineHeaderRows combines a category header row and
+// a field header row of a two-row header into single column titles
+// like "Category / Field" using HeaderRowsSeparator.
+//
+// The category of a group of columns is usually only written
+// in the first column of the group, like exported merged cells,
+// so an empty category field continues the category of the column before.
+// Columns before the first category or with an empty field title
+// get only the field or category title.
+func CombineHeaderRows(categoryRow, fieldRow []string) []string {
+	titles := make([]string, max(len(categoryRow), len(fieldRow)))
+	category := ""
+	for i := range titles {
+		if i < len(categoryRow) && strings.TrimSpace(categoryRow[i]) != "" {
+			category = strings.TrimSpace(categoryRow[i])
+		}
+		field := ""
+		if i < len(fieldRow) {
+			field = strings.TrimSpace(fieldRow[i])
+		}
+		switch {
+		case category == "":
+			titles[i] = field
+		case field == "":
+			titles[i] = category
+		default:
+			titles[i] = category + HeaderRowsSeparator + field
+		}
+	}
+	return titles
+}
+
+// MappingFromHeaderRows returns the combined titles of a two-row header,
+// see CombineHeaderRows, and the ColumnMapping for the fields
+// of structType matching the combined titles, see MappingFromStruct.
+// The struct tag named tag of a field has to contain
+// the combined title like `col:"Address / City"`.
+func MappingFromHeaderRows(structType reflect.Type, categoryRow, fieldRow []string, tag string) (titles []string, mapping []ColumnMapping, err error) {
+	titles = CombineHeaderRows(categoryRow, fieldRow)
+	mapping, err = MappingFromStruct(structType, titles, tag)
+	if err != nil {
+		return nil, nil, err
+	}
+	return titles, mapping, nil
+}
@@ -0,0 +1,57 @@
+package csv
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCombineHeaderRows(t *testing.T) {
+	categoryRow := []string{"", "Billing", "", "Shipping", ""}
+	fieldRow := []string{"ID", "City", "Zip", "City", "Zip", "Note"}
+	assert.Equal(t, []string{
+		"ID",
+		"Billing / City",
+		"Billing / Zip",
+		"Shipping / City",
+		"Shipping / Zip",
+		"Shipping / Note",
+	}, CombineHeaderRows(categoryRow, fieldRow))
+
+	assert.Equal(t, []string{"Total", "Total / Net"}, CombineHeaderRows([]string{" Total "}, []string{"", "Net"}))
+}
+
+func TestMappingFromHeaderRows(t *testing.T) {
+	type row struct {
+		ID           int
+		BillingCity  string `col:"Billing / City"`
+		ShippingCity string `col:"Shipping / City,required"`
+		ShippingZip  string `col:"Shipping / Zip"`
+	}
+	rows := [][]string{
+		{"", "Billing", "", "Shipping", ""},
+		{"ID", "City", "Zip", "City", "Zip"},
+		{"1", "Vienna", "1010", "Graz", "8010"},
+	}
+	titles, mapping, err := MappingFromHeaderRows(reflect.TypeOf(row{}), rows[0], rows[1], "col")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ID", "Billing / City", "Billing / Zip", "Shipping / City", "Shipping / Zip"}, titles)
+	assert.Equal(t, []ColumnMapping{
+		{Index: 0, StructField: "ID"},
+		{Index: 1, StructField: "BillingCity"},
+		{Index: 3, StructField: "ShippingCity"},
+		{Index: 4, StructField: "ShippingZip"},
+	}, mapping)
+
+	reader, err := NewReaderFromRows(rows[2:], NewFormat(","), "", nil, mapping)
+	require.NoError(t, err, "NewReaderFromRows")
+	var dest row
+	err = reader.ReadRow(0, reflect.ValueOf(&dest).Elem())
+	require.NoError(t, err, "ReadRow")
+	assert.Equal(t, row{1, "Vienna", "Graz", "8010"}, dest)
+
+	_, _, err = MappingFromHeaderRows(reflect.TypeOf(row{}), nil, rows[1], "col")
+	assert.Error(t, err, "missing required combined title")
+}
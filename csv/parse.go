@@ -12,6 +12,17 @@ import (
 
 // ParseDetectFormat returns a slice of strings per row with the format detected via the FormatDetectionConfig.
 func ParseDetectFormat(data []byte, configOrNil *FormatDetectionConfig) (rows [][]string, format *Format, err error) {
+	rows, result, err := ParseDetectFormatVerbose(data, configOrNil)
+	if result != nil {
+		format = result.Format
+	}
+	return rows, format, err
+}
+
+// ParseDetectFormatVerbose works like ParseDetectFormat but returns
+// a FormatDetectionResult with the detected Format
+// and statistics about the detection.
+func ParseDetectFormatVerbose(data []byte, configOrNil *FormatDetectionConfig) (rows [][]string, result *FormatDetectionResult, err error) {
 	defer errs.WrapWithFuncParams(&err, data, configOrNil)
 	defer errs.RecoverPanicAsError(&err)
 
@@ -20,13 +31,13 @@ func ParseDetectFormat(data []byte, configOrNil *FormatDetectionConfig) (rows []
 		config = NewFormatDetectionConfig()
 	}
 
-	format, lines, err := detectFormatAndSplitLines(data, config)
+	result, lines, err := detectFormatAndSplitLines(data, config)
 	if err != nil {
-		return nil, format, err
+		return nil, result, err
 	}
 
-	rows, err = readLines(lines, []byte(format.Separator), "\n")
-	return rows, format, err
+	rows, err = readLines(lines, []byte(result.Format.Separator), result.Format.QuoteChar(), result.Format.Escape, false, "\n")
+	return rows, result, err
 }
 
 // ParseFileDetectFormat returns a slice of strings per row with the format detected via the FormatDetectionConfig.
@@ -49,9 +60,7 @@ func ParseWithFormat(data []byte, format *Format) (rows [][]string, err error) {
 		return nil, err
 	}
 
-	if format.Encoding == "UTF-8" {
-		data = charset.TrimBOM(data, charset.BOMUTF8)
-	} else {
+	if format.Encoding != "UTF-8" {
 		enc, err := charset.GetEncoding(format.Encoding)
 		if err != nil {
 			return nil, err
@@ -61,10 +70,12 @@ func ParseWithFormat(data []byte, format *Format) (rows [][]string, err error) {
 			return nil, err
 		}
 	}
+	data = trimUTF8BOMs(data)
 
 	data = sanitizeUTF8(data)
 
 	lines := bytes.Split(data, []byte(format.Newline))
+	lines = removeCommentLines(lines, format.CommentPrefix)
 	if len(lines) > 0 {
 		if headerSep := parseSepHeaderLine(lines[0]); headerSep != "" {
 			if headerSep != format.Separator {
@@ -74,7 +85,7 @@ func ParseWithFormat(data []byte, format *Format) (rows [][]string, err error) {
 		}
 	}
 
-	return readLines(lines, []byte(format.Separator), "\n")
+	return readLines(lines, []byte(format.Separator), format.QuoteChar(), format.Escape, format.CollapseDelimiters, "\n")
 }
 
 func ParseFileWithFormat(ctx context.Context, csvFile fs.FileReader, format *Format) (rows [][]string, err error) {
@@ -88,14 +99,15 @@ func ParseFileWithFormat(ctx context.Context, csvFile fs.FileReader, format *For
 	return ParseWithFormat(data, format)
 }
 
-func detectFormatAndSplitLines(data []byte, config *FormatDetectionConfig) (format *Format, lines [][]byte, err error) {
+func detectFormatAndSplitLines(data []byte, config *FormatDetectionConfig) (result *FormatDetectionResult, lines [][]byte, err error) {
 	defer errs.WrapWithFuncParams(&err, data, config)
 
 	if config == nil {
 		panic("config must not be nil")
 	}
 
-	format = new(Format)
+	format := new(Format)
+	result = &FormatDetectionResult{Format: format}
 
 	///////////////////////////////////////////////////////////////////////////
 	// Detect charset encoding
@@ -109,13 +121,29 @@ func detectFormatAndSplitLines(data []byte, config *FormatDetectionConfig) (form
 		encodings = append(encodings, enc)
 	}
 
-	data, format.Encoding, err = charset.AutoDecode(data, encodings, config.EncodingTests)
-	if err != nil {
-		return nil, nil, err
+	// Strip a UTF-8, UTF-16LE, or UTF-16BE BOM explicitly
+	// before any detection so that it can't end up
+	// as part of the first field, and use its encoding
+	if bom, rest := charset.SplitBOM(data); bom != charset.NoBOM {
+		enc, err := bom.Encoding()
+		if err != nil {
+			return nil, nil, err
+		}
+		data, err = enc.Decode(rest)
+		if err != nil {
+			return nil, nil, err
+		}
+		format.Encoding = bom.String()
+	} else {
+		data, format.Encoding, err = charset.AutoDecode(data, encodings, config.EncodingTests)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 	if format.Encoding == "" {
 		format.Encoding = "UTF-8"
 	}
+	data = trimUTF8BOMs(data)
 
 	data = sanitizeUTF8(data)
 
@@ -138,8 +166,11 @@ func detectFormatAndSplitLines(data []byte, config *FormatDetectionConfig) (form
 	// }
 
 	// Simple rule: if there are \r\n line endings
-	// then take those because that's the standard
-	if bytes.Contains(data, []byte{'\r', '\n'}) {
+	// then take those because that's the standard,
+	// except when the first line ends with the rare \n\r
+	if firstNewline := bytes.IndexAny(data, "\r\n"); firstNewline >= 0 && bytes.HasPrefix(data[firstNewline:], []byte{'\n', '\r'}) {
+		format.Newline = "\n\r"
+	} else if bytes.Contains(data, []byte{'\r', '\n'}) {
 		format.Newline = "\r\n"
 	} else {
 		format.Newline = "\n"
@@ -149,22 +180,25 @@ func detectFormatAndSplitLines(data []byte, config *FormatDetectionConfig) (form
 	// Detect separator
 
 	lines = bytes.Split(data, []byte(format.Newline))
+	lines = removeCommentLines(lines, config.CommentPrefix)
+	format.CommentPrefix = config.CommentPrefix
 
 	if len(lines) > 0 {
 		format.Separator = parseSepHeaderLine(lines[0])
 		if format.Separator != "" {
-			return format, lines[1:], nil
+			for _, line := range lines[1:] {
+				if len(bytes.Trim(line, "\r\n")) > 0 {
+					result.NumNonEmptyLines++
+				}
+			}
+			result.SeparatorHeader = true
+			result.Confidence = 1
+			return result, lines[1:], nil
 		}
 	}
 
-	type sepCounts struct {
-		commas     int
-		semicolons int
-		tabs       int
-	}
-
 	var (
-		sep sepCounts
+		sep = &result.SeparatorCounts
 		// lineSepCounts  []sepCounts
 		// numSeperators    int
 		numNonEmptyLines int
@@ -186,9 +220,9 @@ func detectFormatAndSplitLines(data []byte, config *FormatDetectionConfig) (form
 		semicolons := bytes.Count(line, []byte{';'})
 		tabs := bytes.Count(line, []byte{'\t'})
 
-		sep.commas += commas
-		sep.semicolons += semicolons
-		sep.tabs += tabs
+		sep.Commas += commas
+		sep.Semicolons += semicolons
+		sep.Tabs += tabs
 		// lineSepCounts = append(lineSepCounts, sepCounts{
 		// 	commas:     commas,
 		// 	semicolons: semicolons,
@@ -196,22 +230,24 @@ func detectFormatAndSplitLines(data []byte, config *FormatDetectionConfig) (form
 		// })
 	}
 
+	result.NumNonEmptyLines = numNonEmptyLines
 	if numNonEmptyLines == 0 {
-		return format, nil, nil
+		return result, nil, nil
 	}
+	result.Confidence = sep.confidence()
 
 	switch {
-	case sep.commas > sep.semicolons && sep.commas > sep.tabs:
+	case sep.Commas > sep.Semicolons && sep.Commas > sep.Tabs:
 		// numSeperators = sep.commas
 		// unusedSeparators = ";\t"
 		format.Separator = ","
 
-	case sep.semicolons > sep.commas && sep.semicolons > sep.tabs:
+	case sep.Semicolons > sep.Commas && sep.Semicolons > sep.Tabs:
 		// numSeperators = sep.semicolons
 		// unusedSeparators = ",\t"
 		format.Separator = ";"
 
-	case sep.tabs > sep.commas && sep.tabs > sep.semicolons:
+	case sep.Tabs > sep.Commas && sep.Tabs > sep.Semicolons:
 		// numSeperators = sep.tabs
 		// unusedSeparators = ",;"
 		format.Separator = "\t"
@@ -222,6 +258,13 @@ func detectFormatAndSplitLines(data []byte, config *FormatDetectionConfig) (form
 		format.Separator = ","
 	}
 
+	///////////////////////////////////////////////////////////////////////////
+	// Detect quote character
+
+	if quote := detectQuote(lines, []byte(format.Separator), config.Quotes); quote != DefaultQuote {
+		format.Quote = quote
+	}
+
 	///////////////////////////////////////////////////////////////////////////
 	// Detect line embedded as single field
 
@@ -269,7 +312,52 @@ func detectFormatAndSplitLines(data []byte, config *FormatDetectionConfig) (form
 	// 	}
 	// }
 
-	return format, lines, nil
+	return result, lines, nil
+}
+
+// detectQuote returns the candidate quote that the most fields
+// begin and end with, or DefaultQuote if no candidate
+// is used more often than DefaultQuote.
+func detectQuote(lines [][]byte, separator []byte, candidates []string) string {
+	quote := DefaultQuote
+	maxCount := 0
+	for _, candidate := range append([]string{DefaultQuote}, candidates...) {
+		if len(candidate) != 1 {
+			continue
+		}
+		q := candidate[0]
+		count := 0
+		for _, line := range lines {
+			for _, field := range bytes.Split(line, separator) {
+				field = bytes.TrimSpace(field)
+				if len(field) >= 2 && field[0] == q && field[len(field)-1] == q {
+					count++
+				}
+			}
+		}
+		if count > maxCount {
+			quote = candidate
+			maxCount = count
+		}
+	}
+	return quote
+}
+
+// removeCommentLines removes lines that begin with commentPrefix
+// after optional whitespace.
+// No lines are removed if commentPrefix is empty.
+func removeCommentLines(lines [][]byte, commentPrefix string) [][]byte {
+	if commentPrefix == "" {
+		return lines
+	}
+	prefix := []byte(commentPrefix)
+	result := lines[:0]
+	for _, line := range lines {
+		if !bytes.HasPrefix(bytes.TrimLeft(line, " \t"), prefix) {
+			result = append(result, line)
+		}
+	}
+	return result
 }
 
 // parseSepHeaderLine parses "sep=," or "SEP=," like header lines
@@ -290,8 +378,20 @@ func parseSepHeaderLine(line []byte) (sep string) {
 	return string(line[4:5])
 }
 
-func readLines(lines [][]byte, separator []byte, newlineReplacement string) (rows [][]string, err error) {
-	defer errs.WrapWithFuncParams(&err, lines, separator, newlineReplacement)
+func readLines(lines [][]byte, separator []byte, quote byte, escape EscapeStyle, collapseDelimiters bool, newlineReplacement string) (rows [][]string, err error) {
+	defer errs.WrapWithFuncParams(&err, lines, separator, quote, escape, collapseDelimiters, newlineReplacement)
+
+	if escape == Backslash {
+		return readLinesBackslash(lines, separator, quote, collapseDelimiters, newlineReplacement), nil
+	}
+	split := func(line []byte) [][]byte {
+		if collapseDelimiters {
+			return splitCollapsed(line, separator, quote)
+		}
+		return bytes.Split(line, separator)
+	}
+
+	escapedQuote := []byte{quote, quote}
 
 	rows = make([][]string, len(lines))
 	for lineIndex, line := range lines {
@@ -299,14 +399,14 @@ func readLines(lines [][]byte, separator []byte, newlineReplacement string) (row
 			continue
 		}
 
-		fields := bytes.Split(line, separator)
+		fields := split(line)
 		for i := 0; i < len(fields); i++ {
 			field := fields[i]
 			if len(field) < 2 {
 				continue
 			}
 
-			leftQuotes, rightQuotes := countQuotesLeftRight(field)
+			leftQuotes, rightQuotes := countQuotesLeftRight(field, quote)
 			switch {
 			case leftQuotes == 0 && rightQuotes == 0:
 				// Unquoted field
@@ -343,8 +443,8 @@ func readLines(lines [][]byte, separator []byte, newlineReplacement string) (row
 						// Newlines are allowed in quoted CSV fields.
 						for joinLineIndex = lineIndex + 1; joinLineIndex < len(lines); joinLineIndex++ {
 							joinLine := lines[joinLineIndex]
-							joinLineFields := bytes.Split(joinLine, separator)
-							if len(joinLineFields) > 0 && bytes.HasSuffix(joinLineFields[0], []byte{'"'}) {
+							joinLineFields := split(joinLine)
+							if len(joinLineFields) > 0 && bytes.HasSuffix(joinLineFields[0], []byte{quote}) {
 								// Found the line where the first field holds the closing quote for the multi-line field
 								break
 							}
@@ -356,7 +456,7 @@ func readLines(lines [][]byte, separator []byte, newlineReplacement string) (row
 						// then empty those lines so line indices are still correct
 
 						joinLine := lines[joinLineIndex]
-						joinLineFields := bytes.Split(joinLine, separator)
+						joinLineFields := split(joinLine)
 
 						// Join lines between lineIndex and joinLineIndex
 						for index := lineIndex + 1; index < joinLineIndex; index++ {
@@ -369,7 +469,7 @@ func readLines(lines [][]byte, separator []byte, newlineReplacement string) (row
 						field = append(field, joinLineFields[0]...)
 
 						// Remove quotes of joined field
-						if field[0] != '"' || field[len(field)-1] != '"' {
+						if field[0] != quote || field[len(field)-1] != quote {
 							panic("csv.Read is broken")
 						}
 						field = field[1 : len(field)-1]
@@ -395,7 +495,17 @@ func readLines(lines [][]byte, separator []byte, newlineReplacement string) (row
 							if len(rField) < 2 {
 								continue
 							}
-							rLeftQuotes, rRightQuotes := countQuotesLeftRight(rField)
+							rLeftQuotes, rRightQuotes := countQuotesLeftRight(rField, quote)
+							if rLeftQuotes%2 == 1 {
+								// The following field begins a new quoted field,
+								// so the field at i is missing its closing quote
+								// like Ford in `"1997","Ford,"E350"`.
+								// Like other malformed quoting this is parsed leniently:
+								// remove its opening quote and don't merge fields,
+								// see the testRows of parse_test.go for examples.
+								field = field[1:]
+								break
+							}
 							var (
 								rLeftOK  = rLeftQuotes == 0 || rLeftQuotes == 2 // right field may only begin with an escaped quote
 								rRightOK = (leftQuotes == 1 && rRightQuotes == 1) || (leftQuotes == 1 && rRightQuotes == 3) || (leftQuotes == 3 && rRightQuotes == 1) || (leftQuotes == 3 && rRightQuotes == 3)
@@ -422,7 +532,7 @@ func readLines(lines [][]byte, separator []byte, newlineReplacement string) (row
 				// /var/domonda-data/documents/c9/727/af8/9cdf4afd/981ad4331d0fb6ca/2019-11-04_08-18-13.602/doc.csv
 			}
 
-			fields[i] = bytes.ReplaceAll(field, []byte(`""`), []byte{'"'})
+			fields[i] = bytes.ReplaceAll(field, escapedQuote, []byte{quote})
 		}
 
 		row := make([]string, len(fields))
@@ -435,27 +545,56 @@ func readLines(lines [][]byte, separator []byte, newlineReplacement string) (row
 	return rows, nil
 }
 
-func countQuotesLeft(str []byte) int {
+// splitCollapsed splits line at runs of consecutive separators
+// that are not within fields beginning with a quote.
+func splitCollapsed(line, separator []byte, quote byte) [][]byte {
+	var (
+		fields   [][]byte
+		start    int
+		inQuotes bool
+	)
+	for i := 0; i < len(line); {
+		switch {
+		case line[i] == quote && inQuotes && i+1 < len(line) && line[i+1] == quote:
+			// Escaped quote within quoted field
+			i += 2
+		case line[i] == quote && (inQuotes || i == start):
+			inQuotes = !inQuotes
+			i++
+		case !inQuotes && bytes.HasPrefix(line[i:], separator):
+			fields = append(fields, line[start:i])
+			for bytes.HasPrefix(line[i:], separator) {
+				i += len(separator)
+			}
+			start = i
+		default:
+			i++
+		}
+	}
+	return append(fields, line[start:])
+}
+
+func countQuotesLeft(str []byte, quote byte) int {
 	for i, c := range str {
-		if c != '"' {
+		if c != quote {
 			return i
 		}
 	}
 	return len(str)
 }
 
-func countQuotesRight(str []byte) int {
+func countQuotesRight(str []byte, quote byte) int {
 	for i := len(str) - 1; i >= 0; i-- {
-		if str[i] != '"' {
+		if str[i] != quote {
 			return len(str) - 1 - i
 		}
 	}
 	return len(str)
 }
 
-func countQuotesLeftRight(str []byte) (left, right int) {
-	left = countQuotesLeft(str)
-	right = countQuotesRight(str)
+func countQuotesLeftRight(str []byte, quote byte) (left, right int) {
+	left = countQuotesLeft(str, quote)
+	right = countQuotesRight(str, quote)
 
 	if left == len(str) {
 		left = (len(str) + 1) / 2
@@ -465,6 +604,16 @@ func countQuotesLeftRight(str []byte) (left, right int) {
 	return left, right
 }
 
+// trimUTF8BOMs removes all UTF-8 BOMs from the start of data,
+// like the BOM of a UTF-8 file or a BOM remaining after decoding,
+// including repeated BOMs from concatenated files.
+func trimUTF8BOMs(data []byte) []byte {
+	for bytes.HasPrefix(data, []byte(charset.BOMUTF8)) {
+		data = data[len(charset.BOMUTF8):]
+	}
+	return data
+}
+
 func sanitizeUTF8(str []byte) []byte {
 	return bytes.Map(
 		func(r rune) rune {
@@ -2,11 +2,14 @@ package csv
 
 import (
 	"context"
+	"encoding/binary"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/ungerik/go-fs"
+
+	"github.com/domonda/go-types/charset"
 )
 
 var testRows = map[string][]string{
@@ -112,6 +115,63 @@ var testRows = map[string][]string{
 		`E350`,
 		`"Super, luxurious truck"`,
 	},
+	`"1997","Ford,"E350","""Super, luxurious truck"""`: {
+		",",
+		`1997`,
+		`Ford`,
+		`E350`,
+		`"Super, luxurious truck"`,
+	},
+	// Neighbours of the missing closing quote above.
+	// The opening quote of the unclosed field is dropped
+	// and the following fields are parsed as usual.
+	`"1997","Ford,"E350"`: {
+		",",
+		`1997`,
+		`Ford`,
+		`E350`,
+	},
+	`"1997","Ford,"Super, luxurious","x"`: {
+		",",
+		`1997`,
+		`Ford`,
+		`Super, luxurious`,
+		`x`,
+	},
+	`"a","b,c,"d"`: {
+		",",
+		`a`,
+		`b`,
+		`c`,
+		`d`,
+	},
+	`a,"b,"c",d`: {
+		",",
+		`a`,
+		`b`,
+		`c`,
+		`d`,
+	},
+	`"a,"b",c`: {
+		",",
+		`a`,
+		`b`,
+		`c`,
+	},
+	// Odd quote count in the middle of a row
+	// is kept as field internal quote
+	`a,b"c,d`: {
+		",",
+		`a`,
+		`b"c`,
+		`d`,
+	},
+	`"a","b"c",d`: {
+		",",
+		`a`,
+		`b"c`,
+		`d`,
+	},
 
 	// "INTERPHONE ""LE 4"""
 	// """Heimbau"" Gemeinnützige Bau-, Wohnungs- u. Siedlungsgenossenscha"
@@ -318,7 +378,7 @@ func TestCountQuotes(t *testing.T) {
 
 	for str, counts := range testData {
 		t.Run(str, func(t *testing.T) {
-			left, right := countQuotesLeftRight([]byte(str))
+			left, right := countQuotesLeftRight([]byte(str), '"')
 			assert.Equal(t, counts[0], left, "left quote count")
 			assert.Equal(t, counts[1], right, "right quote count")
 		})
@@ -345,3 +405,120 @@ func Test_parseSepHeaderLine(t *testing.T) {
 		})
 	}
 }
+
+func TestParseSingleQuotes(t *testing.T) {
+	data := []byte("'1997','Ford','Super, luxurious truck'\n'2000','It''s','\"Quoted\"'\n")
+	expected := [][]string{
+		{"1997", "Ford", "Super, luxurious truck"},
+		{"2000", "It's", `"Quoted"`},
+	}
+
+	_, format, err := ParseDetectFormat(data, nil)
+	require.NoError(t, err, "ParseDetectFormat")
+	assert.Empty(t, format.Quote, "single quote detection is opt-in")
+
+	config := NewFormatDetectionConfig()
+	config.Quotes = []string{"'"}
+	rows, format, err := ParseDetectFormat(data, config)
+	require.NoError(t, err, "ParseDetectFormat")
+	assert.Equal(t, ",", format.Separator, "separator")
+	assert.Equal(t, "'", format.Quote, "quote")
+	assert.Equal(t, expected, RemoveEmptyRows(rows))
+
+	format = NewFormat(",")
+	format.Quote = "'"
+	format.Newline = "\n"
+	rows, err = ParseWithFormat(data, format)
+	require.NoError(t, err, "ParseWithFormat")
+	assert.Equal(t, expected, RemoveEmptyRows(rows))
+}
+
+func TestParseNewlineNR(t *testing.T) {
+	data := []byte("A,B\n\rC,\"D\nE\"\n\rF,G")
+
+	rows, format, err := ParseDetectFormat(data, nil)
+	require.NoError(t, err, "ParseDetectFormat")
+	assert.Equal(t, "\n\r", format.Newline)
+	assert.Equal(t, [][]string{{"A", "B"}, {"C", "D\nE"}, {"F", "G"}}, rows)
+
+	format = NewFormat(",")
+	format.Newline = "\n\r"
+	rows, err = ParseWithFormat(data, format)
+	require.NoError(t, err, "ParseWithFormat")
+	assert.Equal(t, [][]string{{"A", "B"}, {"C", "D\nE"}, {"F", "G"}}, rows)
+}
+
+func TestParseDetectFormatVerbose(t *testing.T) {
+	data := []byte("A;B;C\r\n1;2,5;3\r\n\r\n4;5;6\r\n")
+
+	rows, result, err := ParseDetectFormatVerbose(data, nil)
+	require.NoError(t, err, "ParseDetectFormatVerbose")
+	assert.Equal(t, ";", result.Format.Separator)
+	assert.Equal(t, SeparatorCounts{Commas: 1, Semicolons: 6}, result.SeparatorCounts)
+	assert.Equal(t, 3, result.NumNonEmptyLines)
+	assert.InDelta(t, 1-1.0/6, result.Confidence, 0.0001)
+	assert.Equal(t, []string{"1", "2,5", "3"}, rows[1])
+
+	_, result, err = ParseDetectFormatVerbose([]byte("sep=|\nA|B\n"), nil)
+	require.NoError(t, err, "ParseDetectFormatVerbose")
+	assert.True(t, result.SeparatorHeader)
+	assert.Equal(t, 1.0, result.Confidence)
+	assert.Equal(t, 1, result.NumNonEmptyLines)
+}
+
+func TestParseCollapseDelimiters(t *testing.T) {
+	data := []byte("a\t\t\tb\n\"x\t\ty\"\t\t5\" screen\n\"say \"\"hi\"\"\"\t\tz\n")
+
+	format := NewFormat("\t")
+	format.Newline = "\n"
+	rows, err := ParseWithFormat(data, format)
+	require.NoError(t, err, "ParseWithFormat")
+	assert.Equal(t, []string{"a", "", "", "b"}, rows[0], "not collapsed by default")
+
+	format.CollapseDelimiters = true
+	rows, err = ParseWithFormat(data, format)
+	require.NoError(t, err, "ParseWithFormat")
+	expected := [][]string{
+		{"a", "b"},
+		{"x\t\ty", `5" screen`},
+		{"say \"hi\"", "z"},
+	}
+	assert.Equal(t, expected, RemoveEmptyRows(rows))
+
+	format.Escape = Backslash
+	rows, err = ParseWithFormat([]byte("a\t\t\tb\n\"x\t\ty\"\t\tz\n"), format)
+	require.NoError(t, err, "ParseWithFormat")
+	assert.Equal(t, [][]string{{"a", "b"}, {"x\t\ty", "z"}}, RemoveEmptyRows(rows))
+}
+
+func TestParseDetectFormatBOM(t *testing.T) {
+	text := "Name;Age\r\nJane;42\r\n"
+	utf16LE, err := charset.UTF16Encoding(binary.LittleEndian).Encode([]byte(text))
+	require.NoError(t, err)
+	utf16BE, err := charset.UTF16Encoding(binary.BigEndian).Encode([]byte(text))
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		data     []byte
+		encoding string
+	}{
+		{name: "UTF-8", data: []byte(string(charset.BOMUTF8) + text), encoding: "UTF-8"},
+		{name: "repeated UTF-8", data: []byte(string(charset.BOMUTF8) + string(charset.BOMUTF8) + text), encoding: "UTF-8"},
+		{name: "UTF-16LE", data: append([]byte(charset.BOMUTF16LE), utf16LE...), encoding: "UTF-16LE"},
+		{name: "UTF-16BE", data: append([]byte(charset.BOMUTF16BE), utf16BE...), encoding: "UTF-16BE"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, format, err := ParseDetectFormat(tt.data, nil)
+			require.NoError(t, err, "ParseDetectFormat")
+			assert.Equal(t, tt.encoding, format.Encoding)
+			assert.Equal(t, ";", format.Separator)
+			assert.Equal(t, []string{"Name", "Age"}, rows[0], "no BOM in first field")
+		})
+	}
+
+	rows, err := ParseWithFormat([]byte(string(charset.BOMUTF8)+string(charset.BOMUTF8)+text), NewFormat(";"))
+	require.NoError(t, err, "ParseWithFormat")
+	assert.Equal(t, []string{"Name", "Age"}, rows[0], "no BOM in first field")
+}
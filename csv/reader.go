@@ -19,6 +19,10 @@ import (
 // 	HeaderNames []string
 // }
 
+// ErrMissingField is returned by Reader.ReadRow if ErrorOnMissingFields
+// is true and a row has no field at the index of a mapped column.
+const ErrMissingField errs.Sentinel = "missing CSV field"
+
 type ColumnMapping struct {
 	Index       int
 	StructField string
@@ -30,6 +34,30 @@ type Reader struct {
 	ScanConfig      *strfmt.ScanConfig     `json:"config"`
 	Modifiers       ModifierList           `json:"modifiers"`
 	Columns         []ColumnMapping        `json:"columns"`
+	// AutoDetectHeader enables the detection of a header row
+	// with DetectHeaderRow when the Reader is used with structtable.Read
+	AutoDetectHeader bool `json:"autoDetectHeader,omitempty"`
+	// FieldPreprocessor is called by ReadRow with the column index
+	// and the string of every mapped field before it is scanned.
+	// The returned string is scanned instead, which allows
+	// to normalize individual columns like stripping currency symbols.
+	// Modifiers are applied to all rows when the Reader is created,
+	// so the FieldPreprocessor receives the already modified strings.
+	// ReadRowStrings and ReadAllStrings return the strings
+	// without FieldPreprocessor applied.
+	FieldPreprocessor func(col int, raw string) string `json:"-"`
+	// ErrorOnMissingFields makes ReadRow return an error wrapping
+	// ErrMissingField if a row is shorter than the index
+	// of a mapped column, so that truncated rows are flagged
+	// instead of leaving the struct fields at their zero values
+	// which is indistinguishable from present empty fields.
+	ErrorOnMissingFields bool `json:"errorOnMissingFields,omitempty"`
+	// OnMissingField is called by ReadRow with the row index
+	// and the column mapping if the row is shorter than the index
+	// of the mapped column. A returned error is returned by ReadRow,
+	// else the field is left unchanged.
+	// If set, ErrorOnMissingFields is not used.
+	OnMissingField func(row int, col ColumnMapping) error `json:"-"`
 
 	rows [][]string
 }
@@ -85,6 +113,20 @@ func (r *Reader) NumRows() int {
 	return len(r.rows)
 }
 
+// DetectNumHeaderRows implements structtable.HeaderRowDetector.
+// It returns one header row if the first row was detected
+// as header row by DetectHeaderRow, else zero.
+// The result is only ok if AutoDetectHeader is true.
+func (r *Reader) DetectNumHeaderRows() (numHeaderRows int, ok bool) {
+	if !r.AutoDetectHeader {
+		return 0, false
+	}
+	if DetectHeaderRow(r.rows) {
+		return 1, true
+	}
+	return 0, true
+}
+
 func (r *Reader) ReadRowStrings(index int) ([]string, error) {
 	if index < 0 || index > len(r.rows) {
 		return nil, errs.Errorf("row index %d out of bounds [0..%d)", index, len(r.rows))
@@ -92,6 +134,11 @@ func (r *Reader) ReadRowStrings(index int) ([]string, error) {
 	return r.rows[index], nil
 }
 
+// ReadAllStrings returns all rows without copying them
+func (r *Reader) ReadAllStrings() ([][]string, error) {
+	return r.rows, nil
+}
+
 func (r *Reader) ReadRow(index int, destStruct reflect.Value) error {
 	if index < 0 || index >= len(r.rows) {
 		return errs.Errorf("row index %d out of bounds [0..%d)", index, len(r.rows))
@@ -99,22 +146,45 @@ func (r *Reader) ReadRow(index int, destStruct reflect.Value) error {
 
 	row := r.rows[index]
 	for _, col := range r.Columns {
-		if col.Index < 0 || col.Index >= len(row) {
+		if col.Index < 0 {
+			continue
+		}
+		if col.Index >= len(row) {
+			err := r.missingField(index, col)
+			if err != nil {
+				return err
+			}
 			continue
 		}
 		destStructField := destStruct.FieldByName(col.StructField)
 		if !destStructField.IsValid() {
 			continue
 		}
-		err := strfmt.Scan(destStructField, row[col.Index], r.ScanConfig)
+		str := row[col.Index]
+		if r.FieldPreprocessor != nil {
+			str = r.FieldPreprocessor(col.Index, str)
+		}
+		err := strfmt.Scan(destStructField, str, r.ScanConfig)
 		if err != nil {
-			return errs.Errorf("error parsing row %d, column %d string %q: %w", index, col.Index, row[col.Index], err)
+			return errs.Errorf("error parsing row %d, column %d string %q: %w", index, col.Index, str, err)
 		}
 	}
 
 	return nil
 }
 
+// missingField handles a missing field of the mapped column col
+// according to OnMissingField and ErrorOnMissingFields
+func (r *Reader) missingField(index int, col ColumnMapping) error {
+	switch {
+	case r.OnMissingField != nil:
+		return r.OnMissingField(index, col)
+	case r.ErrorOnMissingFields:
+		return errs.Errorf("row %d has %d fields, %w at column %d for struct field %s", index, len(r.rows[index]), ErrMissingField, col.Index, col.StructField)
+	}
+	return nil
+}
+
 // // Read reads from an io.Reader to a structSlicePtr
 // func (r *Reader) Read(reader io.Reader, structSlicePtr interface{}) (err error) {
 // 	defer errs.WrapWithFuncParams(&err, reader, structSlicePtr)
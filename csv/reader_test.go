@@ -0,0 +1,115 @@
+package csv
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-structtable"
+)
+
+func TestReaderReadAllStrings(t *testing.T) {
+	rows := [][]string{{"Name", "Count"}, {"Apple", "3"}}
+	reader, err := NewReaderFromRows(rows, NewFormat(","), "", nil, nil)
+	assert.NoError(t, err, "NewReaderFromRows")
+
+	all, err := reader.ReadAllStrings()
+	assert.NoError(t, err, "ReadAllStrings")
+	assert.Equal(t, rows, all)
+
+	all, err = structtable.ReadAllRowStrings(reader)
+	assert.NoError(t, err, "ReadAllRowStrings")
+	assert.Equal(t, rows, all)
+}
+
+func TestReaderFieldPreprocessor(t *testing.T) {
+	type row struct {
+		Name   string
+		Amount float64
+	}
+	rows := [][]string{{"€ Apple", "€1,234.5"}, {"Pear", "1.5E3"}}
+	reader, err := NewReaderFromRows(rows, NewFormat(","), "", nil, []ColumnMapping{{0, "Name"}, {1, "Amount"}})
+	assert.NoError(t, err, "NewReaderFromRows")
+
+	var dest row
+	err = reader.ReadRow(0, reflect.ValueOf(&dest).Elem())
+	assert.Error(t, err, "currency symbol without FieldPreprocessor")
+
+	reader.FieldPreprocessor = func(col int, raw string) string {
+		if col != 1 {
+			return raw
+		}
+		return strings.NewReplacer("€", "", ",", "").Replace(raw)
+	}
+	err = reader.ReadRow(0, reflect.ValueOf(&dest).Elem())
+	assert.NoError(t, err, "ReadRow")
+	assert.Equal(t, row{"€ Apple", 1234.5}, dest, "only column 1 preprocessed")
+
+	err = reader.ReadRow(1, reflect.ValueOf(&dest).Elem())
+	assert.NoError(t, err, "ReadRow")
+	assert.Equal(t, row{"Pear", 1500}, dest)
+
+	all, err := reader.ReadAllStrings()
+	assert.NoError(t, err, "ReadAllStrings")
+	assert.Equal(t, rows, all, "strings are not preprocessed")
+}
+
+func TestReaderMissingFields(t *testing.T) {
+	type row struct {
+		Name string
+		Note string
+	}
+	rows := [][]string{{"A", ""}, {"B"}}
+	reader, err := NewReaderFromRows(rows, NewFormat(","), "", nil, []ColumnMapping{{0, "Name"}, {1, "Note"}})
+	assert.NoError(t, err, "NewReaderFromRows")
+
+	var dest row
+	err = reader.ReadRow(1, reflect.ValueOf(&dest).Elem())
+	assert.NoError(t, err, "missing fields are skipped by default")
+	assert.Equal(t, row{Name: "B"}, dest)
+
+	reader.ErrorOnMissingFields = true
+	err = reader.ReadRow(0, reflect.ValueOf(&dest).Elem())
+	assert.NoError(t, err, "empty field is present")
+	err = reader.ReadRow(1, reflect.ValueOf(&dest).Elem())
+	assert.ErrorIs(t, err, ErrMissingField)
+
+	var missing []ColumnMapping
+	reader.OnMissingField = func(row int, col ColumnMapping) error {
+		missing = append(missing, col)
+		return nil
+	}
+	err = reader.ReadRow(1, reflect.ValueOf(&dest).Elem())
+	assert.NoError(t, err, "OnMissingField takes precedence")
+	assert.Equal(t, []ColumnMapping{{1, "Note"}}, missing)
+}
+
+func TestMappingFromStruct(t *testing.T) {
+	type row struct {
+		Name      string `col:"Full Name,required"`
+		Count     int
+		UnitPrice float64
+		Ignored   string `col:"-"`
+	}
+	header := []string{"Unit Price", "Ignored", "Full Name", "Count"}
+
+	mapping, err := MappingFromStruct(reflect.TypeOf(row{}), header, "col")
+	assert.NoError(t, err)
+	assert.Equal(t, []ColumnMapping{
+		{Index: 2, StructField: "Name"},
+		{Index: 3, StructField: "Count"},
+		{Index: 0, StructField: "UnitPrice"},
+	}, mapping)
+
+	_, err = MappingFromStruct(reflect.TypeOf(row{}), []string{"full name", "count"}, "col")
+	assert.Error(t, err, "missing required column")
+
+	mapping, err = MappingFromStructIgnoreCase(reflect.TypeOf(row{}), []string{"full name", "count"}, "col")
+	assert.NoError(t, err)
+	assert.Equal(t, []ColumnMapping{
+		{Index: 0, StructField: "Name"},
+		{Index: 1, StructField: "Count"},
+	}, mapping)
+}
@@ -4,10 +4,13 @@ import (
 	"bytes"
 	"errors"
 	"io"
+	"reflect"
 	"strings"
+	"time"
 
 	"github.com/domonda/go-structtable"
 	"github.com/domonda/go-types/charset"
+	"github.com/domonda/go-types/float"
 	"github.com/domonda/go-types/strfmt"
 )
 
@@ -19,22 +22,42 @@ var (
 type Renderer struct {
 	*structtable.TextRenderer
 
+	bom            bool
+	encoding       charset.Encoding
 	headerComment  []byte
+	commentPrefix  string
+	commentLines   []string
 	delimiter      []byte
 	quoteAllFields bool
 	// quoteTextFields  bool
 	quoteEmptyFields bool
+	escape           EscapeStyle
 	newLine          []byte
+	trailingNewline  bool
+	// pendingNewline is set when a row was rendered
+	// without its terminating newline yet
+	pendingNewline bool
+	// excelText is the Excel text prefix by column index
+	excelText map[int]string
+	// excelTextAll is the Excel text prefix for all columns
+	excelTextAll *string
+	// newlineReplacer replaces newlines in fields if not nil
+	newlineReplacer *strings.Replacer
+	// typeHeader is written as line after the header row
+	typeHeader []DataType
 }
 
 func NewRenderer(config *strfmt.FormatConfig) *Renderer {
 	csv := &Renderer{
+		bom:            true,
 		headerComment:  nil,
+		commentPrefix:  "#",
 		delimiter:      []byte{';'},
 		quoteAllFields: false,
 		// quoteTextFields:  false,
 		quoteEmptyFields: false,
 		newLine:          []byte{'\r', '\n'},
+		trailingNewline:  true,
 	}
 	csv.TextRenderer = structtable.NewTextRenderer(csv, config)
 	return csv
@@ -43,6 +66,10 @@ func NewRenderer(config *strfmt.FormatConfig) *Renderer {
 func (csv *Renderer) WithFormat(format *Format) *Renderer {
 	csv.delimiter = []byte(format.Separator)
 	csv.newLine = []byte(format.Newline)
+	csv.escape = format.Escape
+	if format.CommentPrefix != "" {
+		csv.commentPrefix = format.CommentPrefix
+	}
 	return csv
 }
 
@@ -54,6 +81,163 @@ func (csv *Renderer) WithDelimiter(delimiter string) *Renderer {
 	return csv
 }
 
+// WithEncoding sets the character encoding of the CSV
+// by name, see charset.GetEncoding.
+// It panics if there is no encoding with the name.
+func (csv *Renderer) WithEncoding(name string) *Renderer {
+	err := csv.SetEncoding(name)
+	if err != nil {
+		panic(err)
+	}
+	return csv
+}
+
+// SetEncoding sets the character encoding of the CSV
+// by name, see charset.GetEncoding.
+// UTF-8 is used by default.
+// If a BOM is enabled, then the BOM of the encoding is written
+// or no BOM if the encoding has none.
+func (csv *Renderer) SetEncoding(name string) error {
+	enc, err := charset.GetEncoding(name)
+	if err != nil {
+		return err
+	}
+	if enc.Name() == "UTF-8" {
+		enc = nil
+	}
+	csv.encoding = enc
+	return nil
+}
+
+// WithNewline sets the line terminator of the rendered rows
+func (csv *Renderer) WithNewline(newline string) *Renderer {
+	csv.newLine = []byte(newline)
+	return csv
+}
+
+// WithNewlineReplacement sets a replacement for "\r\n", "\n",
+// and "\r" line breaks within fields, for example " " or `\n`,
+// for tools that can't read quoted fields with line breaks.
+// By default line breaks are kept within quoted fields.
+// An empty replacement disables the replacement
+// and keeps line breaks instead of removing them.
+func (csv *Renderer) WithNewlineReplacement(replacement string) *Renderer {
+	if replacement == "" {
+		csv.newlineReplacer = nil
+		return csv
+	}
+	csv.newlineReplacer = strings.NewReplacer("\r\n", replacement, "\n", replacement, "\r", replacement)
+	return csv
+}
+
+// WithTrailingNewline sets if the last row
+// is terminated by the newline of the CSV.
+// Every other row is always terminated by the newline.
+// A trailing newline is written by default.
+func (csv *Renderer) WithTrailingNewline(trailingNewline bool) *Renderer {
+	csv.trailingNewline = trailingNewline
+	return csv
+}
+
+// WithBOM sets if a byte order mark of the encoding
+// is written at the beginning of the CSV.
+// A BOM is written by default because Excel
+// needs it to recognize UTF-8 encoded CSV files.
+func (csv *Renderer) WithBOM(bom bool) *Renderer {
+	csv.bom = bom
+	return csv
+}
+
+// WithColumnFloatFormat sets the float format used
+// for the column with the index col instead of
+// the Float format of the renderer's config.
+func (csv *Renderer) WithColumnFloatFormat(col int, format float.FormatDef) *Renderer {
+	if csv.ColumnFloatFormat == nil {
+		csv.ColumnFloatFormat = make(map[int]float.FormatDef)
+	}
+	csv.ColumnFloatFormat[col] = format
+	return csv
+}
+
+// WithColumnBool sets the strings used for true and false
+// values of the column with the index col instead of
+// the True and False strings of the renderer's config.
+func (csv *Renderer) WithColumnBool(col int, trueStr, falseStr string) *Renderer {
+	if csv.ColumnBool == nil {
+		csv.ColumnBool = make(map[int][2]string)
+	}
+	csv.ColumnBool[col] = [2]string{trueStr, falseStr}
+	return csv
+}
+
+// WithIntThousandsSep sets the separator used to group
+// the digits of integers by thousands.
+// Zero, the default, renders integers without grouping.
+func (csv *Renderer) WithIntThousandsSep(sep rune) *Renderer {
+	csv.IntThousandsSep = sep
+	return csv
+}
+
+// WithEnumFormatter renders integer values of enumType
+// with the names of the passed lookup table.
+// Values missing in the lookup table are rendered as numbers.
+func (csv *Renderer) WithEnumFormatter(enumType reflect.Type, names map[int64]string) *Renderer {
+	if csv.EnumFormatters == nil {
+		csv.EnumFormatters = make(structtable.EnumFormatters)
+	}
+	csv.EnumFormatters[enumType] = names
+	return csv
+}
+
+// WithNonFiniteFloats sets the strings rendered for NaN
+// and positive or negative infinite float values.
+// By default they are rendered as empty strings.
+func (csv *Renderer) WithNonFiniteFloats(nanString, infString string) *Renderer {
+	csv.NaNString = nanString
+	csv.InfString = infString
+	return csv
+}
+
+// WithBlankZero renders zero values of integer and float
+// kind types like int, float64, or money.Amount as empty fields.
+func (csv *Renderer) WithBlankZero(blankZero bool) *Renderer {
+	csv.BlankZero = blankZero
+	return csv
+}
+
+// WithSliceJoiner renders slices and arrays as their
+// formatted elements joined with joiner, like "a, b, c"
+// for structtable.DefaultSliceJoiner which is the default.
+// An empty joiner renders slices and arrays as JSON.
+func (csv *Renderer) WithSliceJoiner(joiner string) *Renderer {
+	csv.SliceJoiner = joiner
+	return csv
+}
+
+// WithLocation sets the location used to format
+// time.Time and nullable.Time values.
+// A nil location formats them in their own location.
+func (csv *Renderer) WithLocation(loc *time.Location) *Renderer {
+	csv.Location = loc
+	return csv
+}
+
+// WithTypeHeader sets data types that are written as additional
+// line after the header row, for example determined by DataTypesOf,
+// so that typed loaders can read the types with ParseDataTypes.
+// No type line is written if the header row is not rendered
+// or if types is empty.
+func (csv *Renderer) WithTypeHeader(types []DataType) *Renderer {
+	csv.typeHeader = types
+	return csv
+}
+
+// WithHeaderComment sets a comment that is rendered unquoted
+// on its own line before the header row.
+// The configured newline is appended to the comment,
+// which has to include any comment marker like "# ".
+// Lines of a multi-line comment have to be separated
+// by the configured newline.
 func (csv *Renderer) WithHeaderComment(headerSuffix string) *Renderer {
 	if headerSuffix == "" {
 		csv.headerComment = nil
@@ -63,6 +247,39 @@ func (csv *Renderer) WithHeaderComment(headerSuffix string) *Renderer {
 	return csv
 }
 
+// WithCommentPrefix sets the prefix for the lines
+// passed to WithCommentLines. The default prefix is "#".
+func (csv *Renderer) WithCommentPrefix(prefix string) *Renderer {
+	csv.commentPrefix = prefix
+	return csv
+}
+
+// WithCommentLines sets lines that will be rendered
+// at the beginning of the CSV, each with the comment prefix
+// and terminated by the newline of the CSV.
+func (csv *Renderer) WithCommentLines(lines []string) *Renderer {
+	csv.commentLines = lines
+	return csv
+}
+
+// NumHeaderLines returns the number of lines rendered
+// before the data rows if the header row is rendered:
+// the comment lines, the lines of the header comment,
+// the header row, and the type header line.
+// Line breaks within quoted header titles don't count
+// as separate lines.
+// See ConcatHeaderLines.
+func (csv *Renderer) NumHeaderLines() int {
+	n := len(csv.commentLines) + 1
+	if comment := bytes.TrimRight(csv.headerComment, "\r\n"); len(comment) > 0 {
+		n += bytes.Count(comment, csv.newLine) + 1
+	}
+	if len(csv.typeHeader) > 0 {
+		n++
+	}
+	return n
+}
+
 func (csv *Renderer) WithQuoteAllFields(quote bool) *Renderer {
 	csv.quoteAllFields = quote
 	return csv
@@ -78,8 +295,46 @@ func (csv *Renderer) WithQuoteEmptyFields(quote bool) *Renderer {
 	return csv
 }
 
+// WithEscapeStyle sets how quotes within fields are escaped.
+// The default is DoubleQuote as specified by RFC 4180,
+// Backslash also escapes backslashes within all fields.
+func (csv *Renderer) WithEscapeStyle(style EscapeStyle) *Renderer {
+	csv.escape = style
+	return csv
+}
+
 func (csv *Renderer) RenderBeginTableText(writer io.Writer) error {
-	_, err := writer.Write([]byte(charset.BOMUTF8))
+	if csv.bom {
+		bom := charset.BOMUTF8
+		if csv.encoding != nil {
+			bom = csv.encoding.BOM()
+		}
+		_, err := writer.Write([]byte(bom))
+		if err != nil {
+			return err
+		}
+	}
+	var buf bytes.Buffer
+	for _, line := range csv.commentLines {
+		buf.WriteString(csv.commentPrefix + line)
+		buf.Write(csv.newLine)
+	}
+	return csv.write(writer, buf.Bytes())
+}
+
+// write writes text encoded with the encoding of the Renderer
+func (csv *Renderer) write(writer io.Writer, text []byte) error {
+	if len(text) == 0 {
+		return nil
+	}
+	if csv.encoding != nil {
+		encoded, err := csv.encoding.Encode(text)
+		if err != nil {
+			return err
+		}
+		text = encoded
+	}
+	_, err := writer.Write(text)
 	return err
 }
 
@@ -94,53 +349,98 @@ func (csv *Renderer) SetDelimiter(delimiter string) error {
 
 func (csv *Renderer) RenderHeaderRowText(writer io.Writer, columnTitles []string) error {
 	if len(csv.headerComment) > 0 {
-		_, err := writer.Write(csv.headerComment)
+		var comment bytes.Buffer
+		if csv.pendingNewline {
+			comment.Write(csv.newLine)
+			csv.pendingNewline = false
+		}
+		comment.Write(bytes.TrimRight(csv.headerComment, "\r\n"))
+		comment.Write(csv.newLine)
+		err := csv.write(writer, comment.Bytes())
 		if err != nil {
 			return err
 		}
 	}
-	return csv.RenderRowText(writer, columnTitles)
+	err := csv.renderLine(writer, columnTitles, true)
+	if err != nil || len(csv.typeHeader) == 0 {
+		return err
+	}
+	types := make([]string, len(csv.typeHeader))
+	for i, t := range csv.typeHeader {
+		types[i] = string(t)
+	}
+	return csv.renderLine(writer, types, true)
 }
 
+// RenderRowText renders the fields as a row.
+// The newline terminating the row is only written
+// before the next row or by RenderEndTableText
+// so that it can be omitted after the last row,
+// see WithTrailingNewline.
 func (csv *Renderer) RenderRowText(writer io.Writer, fields []string) error {
+	return csv.renderLine(writer, fields, false)
+}
+
+func (csv *Renderer) renderLine(writer io.Writer, fields []string, isHeader bool) error {
+	// Render the line as UTF-8 and encode it as a whole
+	var line bytes.Buffer
+	if csv.pendingNewline {
+		line.Write(csv.newLine)
+	}
 	for i, field := range fields {
 		if i > 0 {
-			_, err := writer.Write(csv.delimiter)
-			if err != nil {
-				return err
-			}
+			line.Write(csv.delimiter)
 		}
 
-		mustQuote := csv.quoteAllFields || (csv.quoteEmptyFields && field == "") || strings.ContainsAny(field, "\"\n"+string(csv.delimiter))
-
-		if mustQuote {
-			_, err := writer.Write(doubleQuote)
-			if err != nil {
-				return err
-			}
+		if csv.newlineReplacer != nil {
+			field = csv.newlineReplacer.Replace(field)
 		}
-
-		_, err := writer.Write(bytes.Replace([]byte(field), doubleQuote, doubleDoubleQuote, -1))
-		if err != nil {
-			return err
+		var mustQuote bool
+		if !isHeader {
+			field, mustQuote = csv.excelTextField(i, field)
 		}
+		mustQuote = mustQuote || csv.quoteAllFields || (csv.quoteEmptyFields && field == "") || strings.ContainsAny(field, "\"\r\n"+string(csv.delimiter))
 
 		if mustQuote {
-			_, err := writer.Write(doubleQuote)
-			if err != nil {
-				return err
-			}
+			line.Write(doubleQuote)
+		}
+		line.Write(escapeField(field, csv.escape))
+		if mustQuote {
+			line.Write(doubleQuote)
 		}
 	}
 
-	_, err := writer.Write(csv.newLine)
-	return err
+	err := csv.write(writer, line.Bytes())
+	if err != nil {
+		return err
+	}
+	csv.pendingNewline = true
+	return nil
 }
 
-func (*Renderer) RenderEndTableText(writer io.Writer) error {
-	return nil
+// RenderEndTableText terminates the last row
+// with a newline if enabled by WithTrailingNewline.
+func (csv *Renderer) RenderEndTableText(writer io.Writer) error {
+	if !csv.pendingNewline {
+		return nil
+	}
+	csv.pendingNewline = false
+	if !csv.trailingNewline {
+		return nil
+	}
+	return csv.write(writer, csv.newLine)
 }
 
-func (*Renderer) MIMEType() string {
+// Reset clears the rendered text so that
+// the Renderer can be reused for another table.
+func (csv *Renderer) Reset() {
+	csv.TextRenderer.Reset()
+	csv.pendingNewline = false
+}
+
+func (csv *Renderer) MIMEType() string {
+	if csv.encoding != nil {
+		return "text/csv; charset=" + csv.encoding.Name()
+	}
 	return "text/csv; charset=UTF-8"
 }
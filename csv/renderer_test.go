@@ -2,13 +2,20 @@ package csv
 
 import (
 	"bytes"
+	"math"
+	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 
 	"github.com/domonda/go-structtable"
 	"github.com/domonda/go-structtable/test"
 	"github.com/domonda/go-types/charset"
+	"github.com/domonda/go-types/date"
+	"github.com/domonda/go-types/float"
+	"github.com/domonda/go-types/money"
+	"github.com/domonda/go-types/nullable"
 	"github.com/domonda/go-types/strfmt"
 )
 
@@ -35,3 +42,518 @@ false;String 4;Bytes 4;4;4;4;214.26387258237492;EUR;38,065.72;EUR 31,805.82;2012
 
 	assert.Equal(t, string(expected), string(result), "Comparing CSV output")
 }
+
+func Test_RenderCSVWithFooter(t *testing.T) {
+	type row struct {
+		Name   string
+		Amount money.Amount
+	}
+	rows := []row{{"A", 1.5}, {"B", 2.25}}
+	sum := func(structSlice any) []reflect.Value {
+		var total money.Amount
+		for _, r := range structSlice.([]row) {
+			total += r.Amount
+		}
+		return []reflect.Value{reflect.ValueOf("Sum"), reflect.ValueOf(total)}
+	}
+
+	renderer := NewRenderer(strfmt.NewFormatConfig())
+	err := structtable.RenderWithFooter(renderer, rows, true, structtable.DefaultReflectColumnTitles, sum)
+	assert.NoError(t, err, "RenderWithFooter")
+
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+
+	const expectedCSV = "Name;Amount\r\nA;1.50\r\nB;2.25\r\nSum;3.75\r\n"
+	assert.Equal(t, string(charset.BOMUTF8)+expectedCSV, string(result))
+}
+
+func Test_RenderCSVTrailingNewline(t *testing.T) {
+	type row struct {
+		Name  string
+		Count int
+	}
+	rows := []row{{"A", 1}, {"B", 2}}
+
+	renderer := NewRenderer(strfmt.NewFormatConfig()).WithBOM(false).WithTrailingNewline(false)
+	err := structtable.Render(renderer, rows, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "Name;Count\r\nA;1\r\nB;2", string(result))
+
+	renderer.Reset()
+	renderer.WithTrailingNewline(true)
+	err = structtable.Render(renderer, rows, false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err = renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "A;1\r\nB;2\r\n", string(result))
+}
+
+func Test_RenderCSVExcelText(t *testing.T) {
+	type row struct {
+		Zip  string
+		Card string
+		Note string
+	}
+	rows := []row{{"01234", "1234567812345678", "007"}, {"1234", "123", "x"}}
+
+	renderer := NewRenderer(strfmt.NewFormatConfig()).WithBOM(false).WithExcelText("=", 0, 1)
+	err := structtable.Render(renderer, rows, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "Zip;Card;Note\r\n\"=\"\"01234\"\"\";\"=\"\"1234567812345678\"\"\";007\r\n1234;123;x\r\n", string(result))
+
+	renderer = NewRenderer(strfmt.NewFormatConfig()).WithBOM(false).WithExcelText("\t")
+	err = structtable.Render(renderer, rows, false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err = renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "\"\t01234\";\"\t1234567812345678\";\"\t007\"\r\n1234;123;x\r\n", string(result))
+}
+
+func Test_RenderCSVNewlineReplacement(t *testing.T) {
+	type row struct {
+		Name string `col:"Full\nName"`
+		Note string
+	}
+	rows := []row{{"A", "line 1\r\nline 2\nline 3"}}
+
+	renderer := NewRenderer(strfmt.NewFormatConfig()).WithBOM(false).WithNewlineReplacement(`\n`)
+	err := structtable.Render(renderer, rows, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "Full\\nName;Note\r\nA;line 1\\nline 2\\nline 3\r\n", string(result))
+
+	renderer = NewRenderer(strfmt.NewFormatConfig()).WithBOM(false)
+	err = structtable.Render(renderer, rows, false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err = renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "A;\"line 1\r\nline 2\nline 3\"\r\n", string(result), "quoted by default")
+
+	renderer = NewRenderer(strfmt.NewFormatConfig()).WithBOM(false).WithNewlineReplacement(" ").WithNewlineReplacement("")
+	err = structtable.Render(renderer, rows, false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err = renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "A;\"line 1\r\nline 2\nline 3\"\r\n", string(result), "empty replacement disables replacing")
+}
+
+func Test_RenderCSVIntThousandsSep(t *testing.T) {
+	type row struct {
+		Int      int
+		Uint     uint64
+		IntPtr   *int
+		Negative int16
+		Float    float64
+	}
+	million := 1000000
+	rows := []row{{1000000, 123456789, &million, -1234, 1234.5}, {999, 0, nil, -100, 0}}
+
+	renderer := NewRenderer(strfmt.NewGermanFormatConfig()).WithBOM(false).WithIntThousandsSep('.')
+	err := structtable.Render(renderer, rows, false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "1.000.000;123.456.789;1.000.000;-1.234;1234,5\r\n999;0;;-100;0\r\n", string(result))
+
+	renderer = NewRenderer(strfmt.NewGermanFormatConfig()).WithBOM(false)
+	err = structtable.Render(renderer, rows[:1], false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err = renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "1000000;123456789;1000000;-1234;1234,5\r\n", string(result), "no grouping by default")
+}
+
+type testStatus int
+
+func Test_RenderCSVEnumFormatter(t *testing.T) {
+	type row struct {
+		Status    testStatus
+		StatusPtr *testStatus
+		Count     int
+	}
+	unknown := testStatus(9)
+	rows := []row{{1, &unknown, 1}, {2, nil, 2}}
+
+	renderer := NewRenderer(strfmt.NewFormatConfig()).WithBOM(false).
+		WithEnumFormatter(reflect.TypeOf(testStatus(0)), map[int64]string{1: "open", 2: "closed"})
+	err := structtable.Render(renderer, rows, false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "open;9;1\r\nclosed;;2\r\n", string(result))
+}
+
+func Test_RenderCSVTypeHeader(t *testing.T) {
+	type row struct {
+		Name    string
+		Count   int
+		Amount  money.Amount
+		Date    date.NullableDate
+		Price   *float64
+		Ignored string `col:"-"`
+	}
+	types := DataTypesOf(reflect.TypeOf(row{}), structtable.DefaultReflectColumnTitles)
+	assert.Equal(t, []DataType{DataTypeString, DataTypeInt, DataTypeMoneyAmount, DataTypeNullableDate, DataTypeNullableFloat}, types)
+
+	renderer := NewRenderer(strfmt.NewFormatConfig()).WithBOM(false).WithTypeHeader(types)
+	err := structtable.Render(renderer, []row{{"A", 1, 2, "2024-01-02", nil, ""}}, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "Name;Count;Amount;Date;Price\r\nSTRING;INT;MONEY_AMOUNT;NULL_DATE;NULL_FLOAT\r\nA;1;2.00;2024-01-02;\r\n", string(result))
+
+	parsed, err := ParseDataTypes([]string{"STRING", "INT", "MONEY_AMOUNT", "NULL_DATE", "NULL_FLOAT"})
+	assert.NoError(t, err, "ParseDataTypes")
+	assert.Equal(t, types, parsed)
+	_, err = ParseDataTypes([]string{"STRING", "UNKNOWN"})
+	assert.Error(t, err, "invalid data type")
+}
+
+func Test_RenderCSVNonFiniteFloats(t *testing.T) {
+	type row struct {
+		Float  float64
+		Amount money.Amount
+		Ptr    *float32
+	}
+	inf := float32(math.Inf(-1))
+	rows := []row{{math.NaN(), money.Amount(math.Inf(1)), &inf}, {1.5, 2, nil}}
+
+	renderer := NewRenderer(strfmt.NewFormatConfig()).WithBOM(false)
+	err := structtable.Render(renderer, rows, false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, ";;\r\n1.5;2.00;\r\n", string(result), "empty by default")
+
+	renderer = NewRenderer(strfmt.NewFormatConfig()).WithBOM(false).WithNonFiniteFloats("N/A", "INF")
+	err = structtable.Render(renderer, rows[:1], false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err = renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "N/A;INF;INF\r\n", string(result))
+}
+
+func Test_RenderUnion(t *testing.T) {
+	type invoice struct {
+		Number string
+		Amount money.Amount
+		Due    string
+	}
+	type creditNote struct {
+		Number  string
+		Reason  string
+		Amount  money.Amount
+		Ignored string `col:"-"`
+	}
+	invoices := []invoice{{"I1", 10, "2024-01-31"}}
+	creditNotes := []*creditNote{{"C1", "Damaged", -5, "x"}}
+
+	renderer := NewRenderer(strfmt.NewFormatConfig())
+	err := RenderUnion(
+		renderer,
+		UnionSource{StructSlice: invoices, ColumnMapper: structtable.DefaultReflectColumnTitles},
+		UnionSource{StructSlice: creditNotes, ColumnMapper: structtable.DefaultReflectColumnTitles},
+	)
+	assert.NoError(t, err, "RenderUnion")
+
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+
+	const expectedCSV = "Number;Amount;Due;Reason\r\nI1;10.00;2024-01-31;\r\nC1;-5.00;;Damaged\r\n"
+	assert.Equal(t, string(charset.BOMUTF8)+expectedCSV, string(result))
+}
+
+func Test_RenderCSVCommentLinesRoundTrip(t *testing.T) {
+	type row struct {
+		Name  string
+		Count int
+	}
+	rows := []row{{"A", 1}, {"#B", 2}}
+
+	renderer := NewRenderer(strfmt.NewFormatConfig()).WithCommentLines([]string{" Exported rows", " Version 1"})
+	err := structtable.Render(renderer, rows, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+
+	const expectedCSV = "# Exported rows\r\n# Version 1\r\nName;Count\r\nA;1\r\n#B;2\r\n"
+	assert.Equal(t, string(charset.BOMUTF8)+expectedCSV, string(result))
+
+	format := NewFormat(";")
+	format.CommentPrefix = "# "
+	parsed, err := ParseWithFormat(result, format)
+	assert.NoError(t, err, "ParseWithFormat")
+	assert.Equal(t, [][]string{{"Name", "Count"}, {"A", "1"}, {"#B", "2"}}, RemoveEmptyRows(parsed))
+}
+
+func Test_RenderCSVWithConfig(t *testing.T) {
+	type row struct {
+		Name   string
+		Amount money.Amount
+	}
+	rows := []row{{"A", 1234.5}}
+
+	englishConfig := strfmt.NewFormatConfig()
+	renderer := NewRenderer(englishConfig)
+	err := structtable.RenderWithConfig(renderer, rows, false, structtable.DefaultReflectColumnTitles, strfmt.NewGermanFormatConfig())
+	assert.NoError(t, err, "RenderWithConfig")
+	assert.Same(t, englishConfig, renderer.FormatConfig(), "original config restored")
+
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, string(charset.BOMUTF8)+"A;1.234,50\r\n", string(result))
+}
+
+func Test_RenderCSVReset(t *testing.T) {
+	type row struct{ Name string }
+
+	renderer := NewRenderer(strfmt.NewFormatConfig())
+	err := structtable.Render(renderer, []row{{"A"}}, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+
+	renderer.Reset()
+	err = structtable.Render(renderer, []row{{"B"}}, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, string(charset.BOMUTF8)+"Name\r\nB\r\n", string(result))
+}
+
+func Test_RenderCSVDialects(t *testing.T) {
+	type row struct {
+		Name  string
+		Value float64
+	}
+	rows := []row{{"a,b", 1.5}, {"c\r\nd", 2}}
+
+	tests := []struct {
+		name     string
+		renderer *Renderer
+		want     string
+	}{
+		{
+			name:     "ExcelEU",
+			renderer: NewExcelEURenderer(nil),
+			want:     string(charset.BOMUTF8) + "Name;Value\r\na,b;1,5\r\n\"c\r\nd\";2\r\n",
+		},
+		{
+			name:     "ExcelUS",
+			renderer: NewExcelUSRenderer(nil),
+			want:     string(charset.BOMUTF8) + "Name,Value\r\n\"a,b\",1.5\r\n\"c\r\nd\",2\r\n",
+		},
+		{
+			name:     "RFC4180",
+			renderer: NewRFC4180Renderer(nil),
+			want:     "Name,Value\r\n\"a,b\",1.5\r\n\"c\r\nd\",2\r\n",
+		},
+		{
+			name:     "Unix",
+			renderer: NewUnixRenderer(nil),
+			want:     "Name,Value\n\"a,b\",1.5\n\"c\r\nd\",2\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := structtable.Render(tt.renderer, rows, true, structtable.DefaultReflectColumnTitles)
+			assert.NoError(t, err, "Render")
+			result, err := tt.renderer.Result()
+			assert.NoError(t, err, "Result")
+			assert.Equal(t, tt.want, string(result))
+		})
+	}
+}
+
+func Test_RenderCSVNullValues(t *testing.T) {
+	type row struct {
+		Date     date.NullableDate
+		Time     time.Time
+		Nullable nullable.NonEmptyString
+	}
+	config := strfmt.NewFormatConfig()
+	config.Nil = "NULL"
+
+	renderer := NewRenderer(config).WithBOM(false)
+	err := structtable.Render(renderer, []row{{}}, false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "NULL;NULL;NULL\r\n", string(result))
+}
+
+func Test_RenderCSVUTF16LE(t *testing.T) {
+	type row struct {
+		Name string
+		City string
+	}
+	rows := []row{{"Jörg", "Wien"}, {"Zoë; \"Z\"", "Köln"}}
+
+	renderer := NewRenderer(strfmt.NewFormatConfig()).WithEncoding("UTF-16LE")
+	assert.Equal(t, "text/csv; charset=UTF-16LE", renderer.MIMEType())
+	err := structtable.Render(renderer, rows, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.True(t, bytes.HasPrefix(result, []byte{0xFF, 0xFE}), "UTF-16LE BOM")
+	assert.Equal(t, []byte{'N', 0, 'a', 0}, result[2:6], "UTF-16LE encoded")
+
+	parsed, format, err := ParseDetectFormat(result, nil)
+	assert.NoError(t, err, "ParseDetectFormat")
+	assert.Equal(t, "UTF-16LE", format.Encoding)
+	assert.Equal(t, ";", format.Separator)
+	assert.Equal(t, [][]string{{"Name", "City"}, {"Jörg", "Wien"}, {"Zoë; \"Z\"", "Köln"}}, parsed[:3])
+}
+
+func Test_RenderCSVColumnFloatFormat(t *testing.T) {
+	type row struct {
+		Quantity float64
+		Price    float64
+		Factor   float64
+	}
+	renderer := NewRenderer(strfmt.NewEnglishFormatConfig()).
+		WithBOM(false).
+		WithColumnFloatFormat(0, strfmt.EnglishFloatFormat(0)).
+		WithColumnFloatFormat(1, float.FormatDef{DecimalSep: '.', Precision: 4, PadPrecision: true})
+	err := structtable.Render(renderer, []row{{3, 1.5, 0.125}}, false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "3;1.5000;0.125\r\n", string(result))
+}
+
+func Test_RenderCSVColumnBool(t *testing.T) {
+	type row struct {
+		Checked bool
+		Active  *bool
+		Other   bool
+	}
+	active := true
+	renderer := NewRenderer(strfmt.NewEnglishFormatConfig()).
+		WithBOM(false).
+		WithColumnBool(0, "✓", "✗").
+		WithColumnBool(1, "Active", "Inactive")
+	err := structtable.Render(renderer, []row{{true, &active, true}, {false, nil, false}}, false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "✓;Active;yes\r\n✗;;no\r\n", string(result))
+}
+
+func Test_RenderCSVLocation(t *testing.T) {
+	type row struct {
+		Time     time.Time
+		Nullable nullable.Time
+		Pointer  *time.Time
+	}
+	utc := time.Date(2024, 1, 2, 23, 30, 0, 0, time.UTC)
+	vienna, err := time.LoadLocation("Europe/Vienna")
+	if err != nil {
+		t.Skip("time zone data not available:", err)
+	}
+	config := strfmt.NewFormatConfig()
+	config.Time = "2006-01-02 15:04 MST"
+
+	renderer := NewRenderer(config).WithBOM(false).WithLocation(vienna)
+	err = structtable.Render(renderer, []row{{utc, nullable.TimeFrom(utc), &utc}, {}}, false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "2024-01-03 00:30 CET;2024-01-03 00:30 CET;2024-01-03 00:30 CET\r\n;;\r\n", string(result))
+
+	renderer = NewRenderer(config).WithBOM(false)
+	err = structtable.Render(renderer, []row{{Time: utc}}, false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err = renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "2024-01-02 23:30 UTC;;\r\n", string(result), "own location without Location")
+}
+
+func Test_RenderCSVEscapeStyleRoundTrip(t *testing.T) {
+	type row struct {
+		Name string
+		Text string
+	}
+	rows := []row{
+		{"Quotes", `Say "Hello"`},
+		{"Backslash", `C:\temp\"x"\`},
+		{"Separator", "a,b"},
+		{"Newline", "Line1\nLine2"},
+		{`"Quoted"`, ""},
+	}
+
+	for _, tc := range []struct {
+		escape   EscapeStyle
+		expected string
+	}{
+		{
+			escape:   DoubleQuote,
+			expected: "Name,Text\nQuotes,\"Say \"\"Hello\"\"\"\nBackslash,\"C:\\temp\\\"\"x\"\"\\\"\nSeparator,\"a,b\"\nNewline,\"Line1\nLine2\"\n\"\"\"Quoted\"\"\",\n",
+		},
+		{
+			escape:   Backslash,
+			expected: "Name,Text\nQuotes,\"Say \\\"Hello\\\"\"\nBackslash,\"C:\\\\temp\\\\\\\"x\\\"\\\\\"\nSeparator,\"a,b\"\nNewline,\"Line1\nLine2\"\n\"\\\"Quoted\\\"\",\n",
+		},
+	} {
+		t.Run(tc.escape.String(), func(t *testing.T) {
+			format := NewFormat(",")
+			format.Newline = "\n"
+			format.Escape = tc.escape
+
+			renderer := NewRenderer(strfmt.NewFormatConfig()).WithFormat(format).WithBOM(false)
+			err := structtable.Render(renderer, rows, true, structtable.DefaultReflectColumnTitles)
+			assert.NoError(t, err, "Render")
+			result, err := renderer.Result()
+			assert.NoError(t, err, "Result")
+			assert.Equal(t, tc.expected, string(result))
+
+			parsed, err := ParseWithFormat(result, format)
+			assert.NoError(t, err, "ParseWithFormat")
+			expected := [][]string{{"Name", "Text"}}
+			for _, r := range rows {
+				expected = append(expected, []string{r.Name, r.Text})
+			}
+			assert.Equal(t, expected, RemoveEmptyRows(parsed))
+		})
+	}
+}
+
+func Test_RenderCSVSliceJoiner(t *testing.T) {
+	type row struct {
+		Tags []string
+		IDs  []int
+	}
+	renderer := NewRenderer(strfmt.NewFormatConfig()).WithBOM(false).WithSliceJoiner(structtable.DefaultSliceJoiner)
+	err := structtable.Render(renderer, []row{{[]string{"a", "b;c"}, []int{1, 2}}}, false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "\"a, b;c\";1, 2\r\n", string(result))
+}
+
+func Test_RenderCSVHeaderComment(t *testing.T) {
+	type row struct {
+		Name string
+		Age  int
+	}
+	for _, comment := range []string{"# Exported people", "# Exported people\r\n"} {
+		renderer := NewRenderer(strfmt.NewFormatConfig()).WithBOM(false).WithHeaderComment(comment)
+		err := structtable.Render(renderer, []row{{"Jane", 42}}, true, structtable.DefaultReflectColumnTitles)
+		assert.NoError(t, err, "Render")
+		result, err := renderer.Result()
+		assert.NoError(t, err, "Result")
+		assert.Equal(t, "# Exported people\r\nName;Age\r\nJane;42\r\n", string(result), "comment on its own line")
+	}
+
+	renderer := NewRenderer(strfmt.NewFormatConfig()).WithBOM(false).WithHeaderComment("# Comment")
+	err := structtable.Render(renderer, []row{{"Jane", 42}}, false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "Jane;42\r\n", string(result), "no comment without header row")
+}
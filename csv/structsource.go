@@ -0,0 +1,156 @@
+package csv
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/domonda/go-errs"
+)
+
+// goTypes maps non nullable data types to Go types
+// and the import paths of their packages
+var goTypes = map[DataType][2]string{
+	DataTypeString:      {"string", ""},
+	DataTypeInt:         {"int", ""},
+	DataTypeFloat:       {"float64", ""},
+	DataTypeMoneyAmount: {"money.Amount", "github.com/domonda/go-types/money"},
+	DataTypeCurrency:    {"money.Currency", "github.com/domonda/go-types/money"},
+	DataTypeDate:        {"date.Date", "github.com/domonda/go-types/date"},
+	DataTypeTime:        {"time.Time", "time"},
+	DataTypeIBAN:        {"bank.IBAN", "github.com/domonda/go-types/bank"},
+	DataTypeBIC:         {"bank.BIC", "github.com/domonda/go-types/bank"},
+}
+
+// GenerateStructSource returns formatted Go source code
+// of a struct type named typeName to read the passed rows into,
+// for example as starting point to support a new import format.
+//
+// The field names are the PascalCase versions of the titles
+// of the header row with the index headerRow, and the titles
+// are used as "col" struct tags.
+// If headerRow is negative, then there is no header row
+// and the fields are named Column1, Column2, and so on without tags.
+// Rows before headerRow are ignored.
+//
+// The field types are inferred from the data types
+// that all non empty fields of a column share, see StringDataTypes.
+// Columns with empty fields get pointer types,
+// except for string columns.
+func GenerateStructSource(rows [][]string, headerRow int, typeName string) (string, error) {
+	if !token.IsIdentifier(typeName) {
+		return "", errs.Errorf("invalid Go type name %q", typeName)
+	}
+	if headerRow >= len(rows) {
+		return "", errs.Errorf("header row index %d out of bounds [0..%d)", headerRow, len(rows))
+	}
+
+	var titles []string
+	dataRows := rows
+	if headerRow >= 0 {
+		titles = rows[headerRow]
+		dataRows = rows[headerRow+1:]
+	}
+	numCols := len(titles)
+	for _, row := range dataRows {
+		numCols = max(numCols, len(row))
+	}
+
+	var (
+		fields    bytes.Buffer
+		imports   []string
+		usedNames = make(map[string]bool)
+	)
+	for col := range numCols {
+		title := ""
+		if col < len(titles) {
+			title = titles[col]
+		}
+		name := goFieldName(title, col)
+		for i := 2; usedNames[name]; i++ {
+			name = goFieldName(title, col) + strconv.Itoa(i)
+		}
+		usedNames[name] = true
+
+		goType := goTypes[DataTypeString]
+		if common := commonColumnDataTypes(dataRows, col); len(common) > 0 {
+			goType = goTypes[common[0]]
+			if columnHasEmptyFields(dataRows, col) {
+				goType[0] = "*" + goType[0]
+			}
+		}
+		if goType[1] != "" && !slices.Contains(imports, goType[1]) {
+			imports = append(imports, goType[1])
+		}
+
+		fmt.Fprintf(&fields, "%s %s", name, goType[0])
+		if col < len(titles) {
+			tag := `col:` + strconv.Quote(title)
+			if strings.ContainsRune(tag, '`') {
+				fmt.Fprintf(&fields, " %s", strconv.Quote(tag))
+			} else {
+				fmt.Fprintf(&fields, " `%s`", tag)
+			}
+		}
+		fields.WriteByte('\n')
+	}
+
+	var source bytes.Buffer
+	if len(imports) > 0 {
+		slices.Sort(imports)
+		source.WriteString("import (\n")
+		for _, path := range imports {
+			fmt.Fprintf(&source, "%q\n", path)
+		}
+		source.WriteString(")\n\n")
+	}
+	fmt.Fprintf(&source, "type %s struct {\n%s}\n", typeName, fields.Bytes())
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return "", errs.Errorf("error formatting generated source: %w", err)
+	}
+	return string(formatted), nil
+}
+
+// goFieldName returns the PascalCase version of title
+// as exported Go identifier or "Column" plus the
+// one based col number if title has no letters.
+func goFieldName(title string, col int) string {
+	var name strings.Builder
+	upper := true
+	for _, r := range title {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			if upper {
+				r = unicode.ToUpper(r)
+				upper = false
+			}
+			name.WriteRune(r)
+		default:
+			upper = true
+		}
+	}
+	if name.Len() == 0 {
+		return "Column" + strconv.Itoa(col+1)
+	}
+	if first := []rune(name.String())[0]; !unicode.IsUpper(first) {
+		// Digits or letters without case can't start an exported name
+		return "Column" + name.String()
+	}
+	return name.String()
+}
+
+func columnHasEmptyFields(rows [][]string, col int) bool {
+	for _, row := range rows {
+		if col >= len(row) || strings.TrimSpace(row[col]) == "" {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,47 @@
+package csv
+
+import (
+	"go/parser"
+	"go/token"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateStructSource(t *testing.T) {
+	rows := [][]string{
+		{"Exported by Test"},
+		{"Invoice No.", "amount", "Due Date", "IBAN", "Note", "2nd Note", "`Note`"},
+		{"1001", "12.5", "2024-01-31", "DE89370400440532013000", "first", "", "`x`"},
+		{"1002", "", "2024-02-29", "AT611904300234573201", "", "", ""},
+	}
+
+	source, err := GenerateStructSource(rows, 1, "Invoice")
+	assert.NoError(t, err)
+	const expected = "import (\n" +
+		"\t\"github.com/domonda/go-types/bank\"\n" +
+		"\t\"github.com/domonda/go-types/date\"\n" +
+		")\n" +
+		"\n" +
+		"type Invoice struct {\n" +
+		"\tInvoiceNo     int       `col:\"Invoice No.\"`\n" +
+		"\tAmount        *float64  `col:\"amount\"`\n" +
+		"\tDueDate       date.Date `col:\"Due Date\"`\n" +
+		"\tIBAN          bank.IBAN `col:\"IBAN\"`\n" +
+		"\tNote          string    `col:\"Note\"`\n" +
+		"\tColumn2ndNote string    `col:\"2nd Note\"`\n" +
+		"\tNote2         string    \"col:\\\"`Note`\\\"\"\n" +
+		"}\n"
+	assert.Equal(t, expected, source)
+	_, err = parser.ParseFile(token.NewFileSet(), "invoice.go", "package csv\n\n"+source, 0)
+	assert.NoError(t, err, "generated source must parse as Go file")
+
+	source, err = GenerateStructSource(rows[2:], -1, "Row")
+	assert.NoError(t, err)
+	assert.Contains(t, source, "type Row struct {\n\tColumn1 int\n")
+
+	_, err = GenerateStructSource(rows, 4, "Invoice")
+	assert.Error(t, err, "header row out of bounds")
+	_, err = GenerateStructSource(rows, 1, "invalid name")
+	assert.Error(t, err, "invalid type name")
+}
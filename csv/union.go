@@ -0,0 +1,83 @@
+package csv
+
+import (
+	"reflect"
+
+	"github.com/domonda/go-structtable"
+)
+
+// UnionSource is a struct slice together with
+// the ColumnMapper for its struct type
+// used as source for RenderUnion.
+type UnionSource struct {
+	StructSlice  any
+	ColumnMapper structtable.ColumnMapper
+}
+
+// RenderUnion renders the rows of multiple struct slices
+// of different types with one header row
+// containing the union of all column titles.
+// Columns with identical titles are merged,
+// the column order follows the first appearance of a title
+// in the order of the sources.
+// Cells of columns that a source does not have are left empty.
+func RenderUnion(renderer *Renderer, sources ...UnionSource) error {
+	type sourceColumns struct {
+		rowReflector structtable.RowReflector
+		// unionIndices maps the source column index
+		// to the column index within the union
+		unionIndices []int
+	}
+
+	var (
+		unionTitles []string
+		unionIndex  = make(map[string]int)
+		columns     = make([]sourceColumns, len(sources))
+	)
+	for i, source := range sources {
+		rows, err := structtable.ReflectRows(source.StructSlice)
+		if err != nil {
+			return err
+		}
+		titles, rowReflector := source.ColumnMapper.ColumnTitlesAndRowReflector(rows.Type().Elem())
+		columns[i].rowReflector = rowReflector
+		columns[i].unionIndices = make([]int, len(titles))
+		for col, title := range titles {
+			index, ok := unionIndex[title]
+			if !ok {
+				index = len(unionTitles)
+				unionIndex[title] = index
+				unionTitles = append(unionTitles, title)
+			}
+			columns[i].unionIndices[col] = index
+		}
+	}
+
+	err := renderer.RenderHeaderRow(unionTitles)
+	if err != nil {
+		return err
+	}
+
+	for i, source := range columns {
+		// Map the reflected values of the source
+		// to the columns of the union
+		unionMapper := structtable.ColumnMapperFunc(func(reflect.Type) ([]string, structtable.RowReflector) {
+			return unionTitles, structtable.RowReflectorFunc(func(structValue reflect.Value) []reflect.Value {
+				// Zero reflect.Value elements are rendered as empty fields
+				unionValues := make([]reflect.Value, len(unionTitles))
+				for col, val := range source.rowReflector.ReflectRow(structValue) {
+					if col < len(source.unionIndices) {
+						unionValues[source.unionIndices[col]] = val
+					}
+				}
+				return unionValues
+			})
+		})
+		err := structtable.Render(renderer, sources[i].StructSlice, false, unionMapper)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,85 @@
+package csv
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/domonda/go-errs"
+	"github.com/domonda/go-types/strfmt"
+)
+
+// URLConfig configures the requests made by NewReaderFromURL
+type URLConfig struct {
+	// UserAgent is the User-Agent header of the request
+	UserAgent string
+	// Timeout is the maximum duration of the request
+	// including reading the response body.
+	// A timeout of zero means no timeout besides the context.
+	Timeout time.Duration
+}
+
+// NewURLConfig returns a URLConfig with the User-Agent
+// "go-structtable" and a timeout of one minute.
+func NewURLConfig() *URLConfig {
+	return &URLConfig{
+		UserAgent: "go-structtable",
+		Timeout:   time.Minute,
+	}
+}
+
+// NewReaderFromURL reads the response body of a HTTP GET
+// request to url like the CSV or TSV export URL
+// of a published Google Sheet.
+// The request is made as configured by config
+// or by NewURLConfig if config is nil,
+// and canceled with ctx or after the timeout of the config.
+// Gzip encoded responses are decompressed.
+// See NewReader for the other arguments.
+func NewReaderFromURL(ctx context.Context, url string, config *URLConfig, format *Format, newlineReplacement string, modifiers ModifierList, columns []ColumnMapping, scanConfig ...*strfmt.ScanConfig) (r *Reader, err error) {
+	defer errs.WrapWithFuncParams(&err, ctx, url, config, format, newlineReplacement, modifiers, columns, scanConfig)
+
+	if config == nil {
+		config = NewURLConfig()
+	}
+	if config.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, config.Timeout)
+		defer cancel()
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	request.Header.Set("User-Agent", config.UserAgent)
+	// Setting Accept-Encoding disables the transparent
+	// decompression of http.Transport, so gzip is handled
+	// below also for servers that send it unrequested
+	request.Header.Set("Accept-Encoding", "gzip")
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return nil, errs.Errorf("HTTP GET %s returned status %s", url, response.Status)
+	}
+
+	var body io.Reader = response.Body
+	if strings.EqualFold(response.Header.Get("Content-Encoding"), "gzip") {
+		gzipReader, err := gzip.NewReader(response.Body)
+		if err != nil {
+			return nil, err
+		}
+		defer gzipReader.Close()
+		body = gzipReader
+	}
+
+	return NewReader(body, format, newlineReplacement, modifiers, columns, scanConfig...)
+}
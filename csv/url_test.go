@@ -0,0 +1,56 @@
+package csv
+
+import (
+	"compress/gzip"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewReaderFromURL(t *testing.T) {
+	const data = "Name\tCount\nApple\t3"
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Has("agent") {
+			assert.Equal(t, "test-agent", r.Header.Get("User-Agent"))
+		} else {
+			assert.Equal(t, NewURLConfig().UserAgent, r.Header.Get("User-Agent"))
+		}
+		switch r.URL.Path {
+		case "/plain":
+			w.Write([]byte(data))
+		case "/gzip":
+			w.Header().Set("Content-Encoding", "gzip")
+			gz := gzip.NewWriter(w)
+			gz.Write([]byte(data))
+			gz.Close()
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+
+	format := NewFormat("\t")
+	format.Newline = "\n"
+	for _, path := range []string{"/plain", "/gzip"} {
+		reader, err := NewReaderFromURL(context.Background(), server.URL+path, nil, format, "", nil, nil)
+		require.NoError(t, err, path)
+		rows, err := reader.ReadAllStrings()
+		require.NoError(t, err, path)
+		assert.Equal(t, [][]string{{"Name", "Count"}, {"Apple", "3"}}, rows, path)
+	}
+
+	_, err := NewReaderFromURL(context.Background(), server.URL+"/missing", nil, format, "", nil, nil)
+	assert.Error(t, err, "status 404")
+
+	_, err = NewReaderFromURL(context.Background(), server.URL+"/plain?agent", &URLConfig{UserAgent: "test-agent"}, format, "", nil, nil)
+	assert.NoError(t, err, "custom User-Agent without timeout")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = NewReaderFromURL(ctx, server.URL+"/plain", nil, format, "", nil, nil)
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,141 @@
+package structtable
+
+import (
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/domonda/go-types/money"
+	"github.com/domonda/go-types/strfmt"
+)
+
+// MaxDecimals is the number of decimal places used by FormatDecimal
+// with a negative precision for numbers without a finite decimal representation.
+var MaxDecimals = 20
+
+// BigRatFormatter is a strfmt.Formatter for big.Rat values
+// that formats them exactly as decimal numbers using
+// the separators and precision of FormatConfig.Float
+// without converting them to float64.
+var BigRatFormatter strfmt.FormatterFunc = func(val reflect.Value, config *strfmt.FormatConfig) string {
+	return FormatDecimal(BigRatValue(val), config.Float.ThousandsSep, config.Float.DecimalSep, config.Float.Precision)
+}
+
+// ExactMoneyAmountFormatter is a strfmt.Formatter for money.Amount values
+// that rounds the shortest decimal representation of the amount
+// to the precision of FormatConfig.MoneyAmount instead of rounding
+// the binary float64 value, so that for example 1.005 is rounded to 1.01
+// and not to 1.00.
+var ExactMoneyAmountFormatter strfmt.FormatterFunc = func(val reflect.Value, config *strfmt.FormatConfig) string {
+	amount := val.Interface().(money.Amount)
+	if !amount.Valid() {
+		return amount.Format(config.MoneyAmount.ThousandsSep, config.MoneyAmount.DecimalSep, config.MoneyAmount.Precision)
+	}
+	rat, _ := new(big.Rat).SetString(strconv.FormatFloat(float64(amount), 'f', -1, 64))
+	return FormatDecimal(rat, config.MoneyAmount.ThousandsSep, config.MoneyAmount.DecimalSep, config.MoneyAmount.Precision)
+}
+
+// SetExactDecimalFormatters sets BigRatFormatter for big.Rat
+// and ExactMoneyAmountFormatter for money.Amount
+// as TypeFormatters of the passed config and returns it.
+func SetExactDecimalFormatters(config *strfmt.FormatConfig) *strfmt.FormatConfig {
+	if config.TypeFormatters == nil {
+		config.TypeFormatters = make(map[reflect.Type]strfmt.Formatter)
+	}
+	config.TypeFormatters[reflect.TypeOf(big.Rat{})] = BigRatFormatter
+	config.TypeFormatters[reflect.TypeOf(money.Amount(0))] = ExactMoneyAmountFormatter
+	return config
+}
+
+// BigRatValue returns the big.Rat of a reflect.Value
+// of type big.Rat or *big.Rat without copying it if possible.
+// Returns nil for a nil pointer.
+func BigRatValue(val reflect.Value) *big.Rat {
+	if val.Kind() == reflect.Ptr {
+		if val.IsNil() {
+			return nil
+		}
+		return val.Interface().(*big.Rat)
+	}
+	if val.CanAddr() {
+		return val.Addr().Interface().(*big.Rat)
+	}
+	rat := val.Interface().(big.Rat)
+	return &rat
+}
+
+// FormatDecimal formats rat as decimal number with precision
+// decimal places rounded to nearest with halves away from zero.
+// A negative precision formats the exact number of decimals
+// or MaxDecimals with trailing zeros removed
+// if rat has no finite decimal representation.
+// If thousandsSep is not zero, then the integer part of the number is grouped
+// with thousandsSep between every group of 3 digits.
+// A nil rat is formatted as empty string.
+func FormatDecimal(rat *big.Rat, thousandsSep, decimalSep rune, precision int) string {
+	if rat == nil {
+		return ""
+	}
+	str := ""
+	if precision >= 0 {
+		str = rat.FloatString(precision)
+	} else if decimals, ok := finiteDecimals(rat); ok {
+		str = rat.FloatString(decimals)
+	} else {
+		str = rat.FloatString(MaxDecimals)
+		str = strings.TrimRight(strings.TrimRight(str, "0"), ".")
+	}
+
+	if decimalSep == 0 {
+		decimalSep = '.'
+	}
+	integer, fraction, hasFraction := strings.Cut(str, ".")
+	sign := ""
+	if strings.HasPrefix(integer, "-") {
+		integer = integer[1:]
+		// No sign for negative numbers rounded to zero
+		if strings.Trim(integer+fraction, "0") != "" {
+			sign = "-"
+		}
+	}
+
+	var b strings.Builder
+	b.Grow(len(str) + len(integer)/3)
+	b.WriteString(sign)
+	for i, digit := range integer {
+		if thousandsSep != 0 && i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteRune(thousandsSep)
+		}
+		b.WriteRune(digit)
+	}
+	if hasFraction {
+		b.WriteRune(decimalSep)
+		b.WriteString(fraction)
+	}
+	return b.String()
+}
+
+// finiteDecimals returns the number of decimal places
+// needed to represent rat exactly, or false if rat
+// has no finite decimal representation.
+func finiteDecimals(rat *big.Rat) (decimals int, ok bool) {
+	denom := new(big.Int).Set(rat.Denom())
+	var (
+		two, five   = big.NewInt(2), big.NewInt(5)
+		twos, fives int
+		mod         big.Int
+	)
+	for mod.Mod(denom, two).Sign() == 0 {
+		denom.Quo(denom, two)
+		twos++
+	}
+	for mod.Mod(denom, five).Sign() == 0 {
+		denom.Quo(denom, five)
+		fives++
+	}
+	if denom.Cmp(big.NewInt(1)) != 0 {
+		return 0, false
+	}
+	return max(twos, fives), true
+}
@@ -0,0 +1,51 @@
+package structtable
+
+import (
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/domonda/go-types/money"
+	"github.com/domonda/go-types/strfmt"
+)
+
+func TestFormatDecimal(t *testing.T) {
+	tests := []struct {
+		rat          string
+		thousandsSep rune
+		decimalSep   rune
+		precision    int
+		want         string
+	}{
+		{rat: "0", decimalSep: '.', precision: -1, want: "0"},
+		{rat: "1/3", decimalSep: '.', precision: -1, want: "0.33333333333333333333"},
+		{rat: "1/8", decimalSep: '.', precision: -1, want: "0.125"},
+		{rat: "1/8", decimalSep: '.', precision: 2, want: "0.13"},
+		{rat: "-1/1000", decimalSep: '.', precision: 2, want: "0.00"},
+		{rat: "1234567.005", thousandsSep: ',', decimalSep: '.', precision: 2, want: "1,234,567.01"},
+		{rat: "-1234567.5", thousandsSep: '.', decimalSep: ',', precision: -1, want: "-1.234.567,5"},
+		{rat: "123", thousandsSep: ',', decimalSep: '.', precision: 0, want: "123"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.rat, func(t *testing.T) {
+			rat, ok := new(big.Rat).SetString(tt.rat)
+			if !ok {
+				t.Fatalf("invalid big.Rat %q", tt.rat)
+			}
+			if got := FormatDecimal(rat, tt.thousandsSep, tt.decimalSep, tt.precision); got != tt.want {
+				t.Errorf("FormatDecimal() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetExactDecimalFormatters(t *testing.T) {
+	config := SetExactDecimalFormatters(strfmt.NewFormatConfig())
+
+	if got, want := strfmt.Format(money.Amount(1.005), config), "1.01"; got != want {
+		t.Errorf("money.Amount formatted as %q, want %q", got, want)
+	}
+	if got, want := strfmt.Format(reflect.ValueOf(big.NewRat(1, 4)), config), "0.25"; got != want {
+		t.Errorf("*big.Rat formatted as %q, want %q", got, want)
+	}
+}
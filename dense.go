@@ -0,0 +1,97 @@
+package structtable
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// EmptyValueConfig configures which values are considered empty
+// by RenderDenseWithConfig in addition to null values
+// as defined by IsNull, which are always empty.
+// Null values include zero time.Time and date values.
+type EmptyValueConfig struct {
+	// EmptyStrings considers strings empty that
+	// contain only whitespace or nothing at all
+	EmptyStrings bool
+	// ZeroNumbers considers integers and floats with value zero empty
+	ZeroNumbers bool
+	// FalseBools considers false bool values empty
+	FalseBools bool
+}
+
+// DefaultEmptyValueConfig is used by RenderDense
+var DefaultEmptyValueConfig = EmptyValueConfig{
+	EmptyStrings: true,
+	ZeroNumbers:  true,
+}
+
+// IsEmpty returns if val is considered empty
+func (c *EmptyValueConfig) IsEmpty(val reflect.Value) bool {
+	if IsNull(val) {
+		return true
+	}
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		val = val.Elem()
+	}
+	switch val.Kind() {
+	case reflect.String:
+		return c.EmptyStrings && strings.TrimSpace(val.String()) == ""
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return c.ZeroNumbers && val.IsZero()
+	case reflect.Bool:
+		return c.FalseBools && !val.Bool()
+	}
+	return false
+}
+
+// RenderDense renders like Render but omits columns
+// where all values are empty according to DefaultEmptyValueConfig.
+// All rows are reflected before rendering to find
+// the empty columns.
+func RenderDense(renderer Renderer, structSlice any, renderTitleRow bool, columnMapper ColumnMapper) error {
+	return RenderDenseWithConfig(renderer, structSlice, renderTitleRow, columnMapper, &DefaultEmptyValueConfig)
+}
+
+// RenderDenseWithConfig renders like Render but omits columns
+// where all values are empty according to emptyConfig.
+// All rows are reflected before rendering to find
+// the empty columns.
+func RenderDenseWithConfig(renderer Renderer, structSlice any, renderTitleRow bool, columnMapper ColumnMapper, emptyConfig *EmptyValueConfig) error {
+	rows, err := ReflectRows(structSlice)
+	if err != nil {
+		return err
+	}
+
+	columnTitles, rowReflector := columnMapper.ColumnTitlesAndRowReflector(rows.Type().Elem())
+
+	var (
+		rowValues   = make([][]reflect.Value, rows.Len())
+		nonEmptyCol = make([]bool, len(columnTitles))
+	)
+	for i := range rowValues {
+		rowValues[i] = rowReflector.ReflectRow(rows.Index(i))
+		for col, val := range rowValues[i] {
+			if col < len(nonEmptyCol) && !nonEmptyCol[col] && !emptyConfig.IsEmpty(val) {
+				nonEmptyCol[col] = true
+			}
+		}
+	}
+
+	filterRow := func(_ int, columnValues []reflect.Value) ([]reflect.Value, error) {
+		return filterColumns(columnValues, nonEmptyCol), nil
+	}
+	return renderRows(renderer, filterColumns(columnTitles, nonEmptyCol), renderTitleRow, slices.All(rowValues), filterRow)
+}
+
+func filterColumns[T any](columns []T, keep []bool) []T {
+	filtered := make([]T, 0, len(columns))
+	for col, column := range columns {
+		if col < len(keep) && keep[col] {
+			filtered = append(filtered, column)
+		}
+	}
+	return filtered
+}
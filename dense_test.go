@@ -0,0 +1,34 @@
+package structtable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderDense(t *testing.T) {
+	type row struct {
+		Name    string
+		Empty   string
+		Zero    int
+		Count   int
+		Time    time.Time
+		Enabled bool
+	}
+	rows := []row{
+		{Name: "a", Count: 0, Enabled: false},
+		{Name: "b", Empty: " ", Count: 2, Enabled: false},
+	}
+
+	r := new(recordingRenderer)
+	err := RenderDense(r, rows, true, DefaultReflectColumnTitles)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Name", "Count", "Enabled"}, r.header)
+	assert.Equal(t, [][]string{{"a", "<int Value>", "<bool Value>"}, {"b", "<int Value>", "<bool Value>"}}, r.rows)
+
+	r = new(recordingRenderer)
+	err = RenderDenseWithConfig(r, rows, true, DefaultReflectColumnTitles, &EmptyValueConfig{FalseBools: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Name", "Empty", "Zero", "Count"}, r.header)
+}
@@ -0,0 +1,43 @@
+package structtable
+
+import "reflect"
+
+// EnumFormatters maps integer types to lookup tables
+// with the names of their values, for example to render
+// status codes of types without a String method
+// with human readable names.
+type EnumFormatters map[reflect.Type]map[int64]string
+
+// Format returns the name of the integer val
+// or val pointed to by val if val is of a type
+// with a lookup table that contains the value.
+// Unsigned values are looked up converted to int64.
+func (e EnumFormatters) Format(val reflect.Value) (name string, ok bool) {
+	if len(e) == 0 {
+		return "", false
+	}
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return "", false
+		}
+		val = val.Elem()
+	}
+	if !val.IsValid() {
+		return "", false
+	}
+	names, ok := e[val.Type()]
+	if !ok {
+		return "", false
+	}
+	var key int64
+	switch {
+	case val.CanInt():
+		key = val.Int()
+	case val.CanUint():
+		key = int64(val.Uint())
+	default:
+		return "", false
+	}
+	name, ok = names[key]
+	return name, ok
+}
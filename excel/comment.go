@@ -0,0 +1,114 @@
+package excel
+
+import (
+	"encoding/xml"
+	"fmt"
+	"reflect"
+	"strings"
+
+	xlsx "github.com/tealeg/xlsx/v3"
+)
+
+// DefaultCommentAuthor is used as author of cell comments
+// if the CommentAuthor of a Renderer is empty.
+const DefaultCommentAuthor = "Author"
+
+// Commented is written as its Value with Note
+// attached to the cell as Excel comment.
+// An empty Note writes only the Value.
+type Commented struct {
+	Value any
+	Note  string
+}
+
+// cellComment is a note attached to a cell of a sheet
+type cellComment struct {
+	row  int
+	col  int
+	note string
+}
+
+// AddCellComment attaches note as Excel comment to the cell
+// at rowIndex and col of the current sheet.
+// The author of the comment is CommentAuthor
+// or DefaultCommentAuthor if CommentAuthor is empty.
+//
+// A negative rowIndex is relative to the number of rows
+// in the current sheet, so -1 is the last rendered row.
+func (excel *Renderer) AddCellComment(rowIndex, col int, note string) error {
+	if rowIndex < 0 {
+		rowIndex += excel.currentSheet.MaxRow
+	}
+	if rowIndex < 0 || col < 0 {
+		return fmt.Errorf("invalid comment cell row %d, column %d", rowIndex, col)
+	}
+	excel.comments[excel.currentSheet] = append(
+		excel.comments[excel.currentSheet],
+		cellComment{row: rowIndex, col: col, note: note},
+	)
+	return nil
+}
+
+func (excel *Renderer) writeCommentedCell(cell *xlsx.Cell, val reflect.Value, config *ExcelFormatConfig) error {
+	commented := val.Interface().(Commented)
+	err := excel.writeCell(cell, reflect.ValueOf(commented.Value))
+	if err != nil || commented.Note == "" {
+		return err
+	}
+	col, row := cell.GetCoordinates()
+	return excel.AddCellComment(row, col, commented.Note)
+}
+
+func (excel *Renderer) commentAuthor() string {
+	if excel.CommentAuthor == "" {
+		return DefaultCommentAuthor
+	}
+	return excel.CommentAuthor
+}
+
+// commentsPart returns the XML of the comments part of a sheet
+func commentsPart(comments []cellComment, author string) []byte {
+	var b strings.Builder
+	b.WriteString(xml.Header)
+	b.WriteString(`<comments xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><authors><author>`)
+	xml.EscapeText(&b, []byte(author))
+	b.WriteString(`</author></authors><commentList>`)
+	for _, comment := range comments {
+		fmt.Fprintf(&b, `<comment ref="%s" authorId="0"><text><r><t xml:space="preserve">`, xlsx.GetCellIDStringFromCoords(comment.col, comment.row))
+		xml.EscapeText(&b, []byte(comment.note))
+		b.WriteString(`</t></r></text></comment>`)
+	}
+	b.WriteString(`</commentList></comments>`)
+	return []byte(b.String())
+}
+
+// vmlDrawingPart returns the legacy VML drawing part
+// that Excel needs to display the comments of a sheet.
+// sheetNumber is used to create unique shape IDs.
+func vmlDrawingPart(comments []cellComment, sheetNumber int) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b,
+		`<xml xmlns:v="urn:schemas-microsoft-com:vml" xmlns:o="urn:schemas-microsoft-com:office:office" xmlns:x="urn:schemas-microsoft-com:office:excel">`+
+			`<o:shapelayout v:ext="edit"><o:idmap v:ext="edit" data="%d"/></o:shapelayout>`+
+			`<v:shapetype id="_x0000_t202" coordsize="21600,21600" o:spt="202" path="m,l,21600r21600,l21600,xe">`+
+			`<v:stroke joinstyle="miter"/><v:path gradientshapeok="t" o:connecttype="rect"/>`+
+			`</v:shapetype>`,
+		sheetNumber,
+	)
+	for i, comment := range comments {
+		fmt.Fprintf(&b,
+			`<v:shape id="_x0000_s%d" type="#_x0000_t202" style="position:absolute;margin-left:59.25pt;margin-top:1.5pt;width:108pt;height:59.25pt;z-index:%d;visibility:hidden" fillcolor="#ffffe1" o:insetmode="auto">`+
+				`<v:fill color2="#ffffe1"/><v:shadow on="t" color="black" obscured="t"/><v:path o:connecttype="none"/>`+
+				`<v:textbox style="mso-direction-alt:auto"><div style="text-align:left"></div></v:textbox>`+
+				`<x:ClientData ObjectType="Note"><x:MoveWithCells/><x:SizeWithCells/>`+
+				`<x:Anchor>%d, 15, %d, 10, %d, 15, %d, 4</x:Anchor><x:AutoFill>False</x:AutoFill>`+
+				`<x:Row>%d</x:Row><x:Column>%d</x:Column></x:ClientData>`+
+				`</v:shape>`,
+			sheetNumber*1024+i+1, i+1,
+			comment.col+1, comment.row, comment.col+3, comment.row+4,
+			comment.row, comment.col,
+		)
+	}
+	b.WriteString(`</xml>`)
+	return []byte(b.String())
+}
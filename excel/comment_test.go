@@ -0,0 +1,53 @@
+package excel
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	xlsx "github.com/tealeg/xlsx/v3"
+
+	"github.com/domonda/go-structtable"
+)
+
+func TestRendererComments(t *testing.T) {
+	type row struct {
+		Name   string
+		Amount Commented
+	}
+
+	renderer, err := NewRenderer("Sheet 1")
+	require.NoError(t, err, "NewRenderer")
+	renderer.CommentAuthor = "Review & Audit"
+
+	rows := []row{
+		{"A", Commented{Value: 1.5, Note: "Unusually <high>"}},
+		{"B", Commented{Value: 2}},
+	}
+	err = structtable.Render(renderer, rows, true, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+	err = renderer.AddCellComment(-1, 0, "Last row")
+	require.NoError(t, err, "AddCellComment")
+
+	result, err := renderer.Result()
+	require.NoError(t, err, "Result")
+	parts := zipParts(t, result)
+
+	comments := parts["xl/comments1.xml"]
+	assert.Contains(t, comments, "<author>Review &amp; Audit</author>")
+	assert.Contains(t, comments, `<comment ref="B2" authorId="0"><text><r><t xml:space="preserve">Unusually &lt;high&gt;</t>`)
+	assert.Contains(t, comments, `<comment ref="A3" authorId="0">`)
+	assert.NotContains(t, comments, `ref="B3"`, "no comment for empty note")
+	assert.Contains(t, parts["xl/drawings/vmlDrawing1.vml"], "<x:Row>1</x:Row><x:Column>1</x:Column>")
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], `<legacyDrawing r:id="rIdVmlDrawing1"/></worksheet>`)
+	assert.Contains(t, parts["xl/worksheets/_rels/sheet1.xml.rels"], `Target="../comments1.xml"`)
+	assert.Contains(t, parts["[Content_Types].xml"], `Extension="vml"`)
+	assert.Contains(t, parts["[Content_Types].xml"], `PartName="/xl/comments1.xml"`)
+
+	// The commented value is written normally
+	file, err := xlsx.OpenBinary(result)
+	require.NoError(t, err, "OpenBinary")
+	cell, err := file.Sheets[0].Cell(1, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "1.5", cell.Value)
+}
@@ -0,0 +1,289 @@
+package excel
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/png"
+	"io"
+	"maps"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+
+	xlsx "github.com/tealeg/xlsx/v3"
+)
+
+// cellImage is an image anchored to a cell of a sheet
+type cellImage struct {
+	row  int
+	col  int
+	data []byte
+	ext  string
+}
+
+// AddRowImage embeds the image data img into the cell
+// at rowIndex and col of the current sheet.
+// The image is stretched to the size of the cell.
+//
+// A negative rowIndex is relative to the number of rows
+// in the current sheet, so -1 is the last rendered row.
+//
+// Supported formats are "png", "jpeg", and "jpg".
+// If format is empty, then it is detected from img.
+func (excel *Renderer) AddRowImage(rowIndex, col int, img []byte, format string) error {
+	if rowIndex < 0 {
+		rowIndex += excel.currentSheet.MaxRow
+	}
+	if rowIndex < 0 || col < 0 {
+		return fmt.Errorf("invalid image cell row %d, column %d", rowIndex, col)
+	}
+	ext, err := imageExtension(img, format)
+	if err != nil {
+		return err
+	}
+	excel.images[excel.currentSheet] = append(
+		excel.images[excel.currentSheet],
+		cellImage{row: rowIndex, col: col, data: img, ext: ext},
+	)
+	return nil
+}
+
+// RegisterImageType registers an ExcelCellWriter in TypeCellWriters
+// that embeds values of imageType as images into their cells
+// using AddRowImage.
+// imageType must be a byte slice type with PNG or JPEG data
+// or implement image.Image, in which case the image is embedded as PNG.
+// Use a named type like `type Logo []byte` to register
+// a byte slice type without changing how other []byte values are written.
+func (excel *Renderer) RegisterImageType(imageType reflect.Type) {
+	excel.TypeCellWriters[imageType] = ExcelCellWriterFunc(excel.writeImageCell)
+}
+
+func (excel *Renderer) writeImageCell(cell *xlsx.Cell, val reflect.Value, config *ExcelFormatConfig) error {
+	var data []byte
+	switch x := val.Interface().(type) {
+	case nil:
+		return nil
+	case image.Image:
+		var buf bytes.Buffer
+		err := png.Encode(&buf, x)
+		if err != nil {
+			return err
+		}
+		data = buf.Bytes()
+	default:
+		if val.Kind() != reflect.Slice || val.Type().Elem().Kind() != reflect.Uint8 {
+			return fmt.Errorf("can't write %s as image", val.Type())
+		}
+		data = val.Bytes()
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	col, row := cell.GetCoordinates()
+	return excel.AddRowImage(row, col, data, "")
+}
+
+func imageExtension(img []byte, format string) (string, error) {
+	if format == "" {
+		format = strings.TrimPrefix(http.DetectContentType(img), "image/")
+	}
+	switch strings.ToLower(format) {
+	case "png":
+		return "png", nil
+	case "jpeg", "jpg":
+		return "jpeg", nil
+	}
+	return "", fmt.Errorf("unsupported image format %q", format)
+}
+
+// writeFile writes the XLSX file to writer.
+// The tealeg/xlsx package does not support images, comments,
+// and writing the 1904 date system, so if needed the written zip archive
+// is copied while the drawing and comment parts are added to it
+// and the date system of the workbook part is patched.
+func (excel *Renderer) writeFile(writer io.Writer) error {
+	if len(excel.images) == 0 && len(excel.comments) == 0 && !excel.file.Date1904 {
+		return excel.file.Write(writer)
+	}
+
+	var buf bytes.Buffer
+	err := excel.file.Write(&buf)
+	if err != nil {
+		return err
+	}
+	zipReader, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		return err
+	}
+
+	// Collect the parts that have to be patched or added
+	var (
+		added         = make(map[string][]byte)
+		addedNames    []string
+		sheetRels     = make(map[string]string) // rels part name to relationship XML
+		sheetParts    = make(map[string]string) // sheet part name to inserted XML
+		drawings      []string
+		commentsParts []string
+		imageExts     = make(map[string]bool)
+		numImages     int
+	)
+	addPart := func(name string, data []byte) {
+		added[name] = data
+		addedNames = append(addedNames, name)
+	}
+	for sheetIndex, sheet := range excel.file.Sheets {
+		// tealeg/xlsx names sheet parts by their one based index
+		sheetPart := fmt.Sprintf("xl/worksheets/sheet%d.xml", sheetIndex+1)
+		sheetRelsPart := fmt.Sprintf("xl/worksheets/_rels/sheet%d.xml.rels", sheetIndex+1)
+
+		if images := excel.images[sheet]; len(images) > 0 {
+			drawingName := fmt.Sprintf("xl/drawings/drawing%d.xml", len(drawings)+1)
+			drawings = append(drawings, drawingName)
+
+			var drawing, drawingRels strings.Builder
+			drawing.WriteString(xml.Header)
+			drawing.WriteString(`<xdr:wsDr xmlns:xdr="http://schemas.openxmlformats.org/drawingml/2006/spreadsheetDrawing" xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">`)
+			drawingRels.WriteString(xml.Header)
+			drawingRels.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+			for i, img := range images {
+				numImages++
+				imageName := fmt.Sprintf("image%d.%s", numImages, img.ext)
+				addPart("xl/media/"+imageName, img.data)
+				imageExts[img.ext] = true
+				fmt.Fprintf(&drawingRels,
+					`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="../media/%s"/>`,
+					i+1, imageName,
+				)
+				fmt.Fprintf(&drawing,
+					`<xdr:twoCellAnchor editAs="oneCell">`+
+						`<xdr:from><xdr:col>%d</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>%d</xdr:row><xdr:rowOff>0</xdr:rowOff></xdr:from>`+
+						`<xdr:to><xdr:col>%d</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>%d</xdr:row><xdr:rowOff>0</xdr:rowOff></xdr:to>`+
+						`<xdr:pic>`+
+						`<xdr:nvPicPr><xdr:cNvPr id="%d" name="Picture %d"/><xdr:cNvPicPr/></xdr:nvPicPr>`+
+						`<xdr:blipFill><a:blip r:embed="rId%d"/><a:stretch><a:fillRect/></a:stretch></xdr:blipFill>`+
+						`<xdr:spPr><a:prstGeom prst="rect"><a:avLst/></a:prstGeom></xdr:spPr>`+
+						`</xdr:pic>`+
+						`<xdr:clientData/>`+
+						`</xdr:twoCellAnchor>`,
+					img.col, img.row, img.col+1, img.row+1,
+					i+2, i+1, i+1,
+				)
+			}
+			drawing.WriteString(`</xdr:wsDr>`)
+			drawingRels.WriteString(`</Relationships>`)
+			addPart(drawingName, []byte(drawing.String()))
+			addPart(fmt.Sprintf("xl/drawings/_rels/drawing%d.xml.rels", len(drawings)), []byte(drawingRels.String()))
+
+			sheetParts[sheetPart] += `<drawing r:id="` + drawingRelID + `"/>`
+			sheetRels[sheetRelsPart] += fmt.Sprintf(
+				`<Relationship Id="%s" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/drawing" Target="../drawings/drawing%d.xml"/>`,
+				drawingRelID, len(drawings),
+			)
+		}
+
+		if comments := excel.comments[sheet]; len(comments) > 0 {
+			commentsName := fmt.Sprintf("xl/comments%d.xml", len(commentsParts)+1)
+			commentsParts = append(commentsParts, commentsName)
+			addPart(commentsName, commentsPart(comments, excel.commentAuthor()))
+			addPart(fmt.Sprintf("xl/drawings/vmlDrawing%d.vml", len(commentsParts)), vmlDrawingPart(comments, sheetIndex+1))
+
+			// legacyDrawing has to follow the drawing element
+			sheetParts[sheetPart] += `<legacyDrawing r:id="` + vmlDrawingRelID + `"/>`
+			sheetRels[sheetRelsPart] += fmt.Sprintf(
+				`<Relationship Id="%s" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/comments" Target="../comments%d.xml"/>`+
+					`<Relationship Id="%s" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/vmlDrawing" Target="../drawings/vmlDrawing%d.vml"/>`,
+				commentsRelID, len(commentsParts), vmlDrawingRelID, len(commentsParts),
+			)
+		}
+	}
+
+	zipWriter := zip.NewWriter(writer)
+	writePart := func(name string, data []byte) error {
+		w, err := zipWriter.Create(name)
+		if err != nil {
+			return err
+		}
+		_, err = w.Write(data)
+		return err
+	}
+	for _, file := range zipReader.File {
+		data, err := readZipFile(file)
+		if err != nil {
+			return err
+		}
+		switch {
+		case file.Name == "[Content_Types].xml":
+			var types strings.Builder
+			for _, ext := range []string{"jpeg", "png"} {
+				if imageExts[ext] && !bytes.Contains(data, []byte(`Extension="`+ext+`"`)) {
+					fmt.Fprintf(&types, `<Default Extension="%[1]s" ContentType="image/%[1]s"/>`, ext)
+				}
+			}
+			for _, drawing := range drawings {
+				fmt.Fprintf(&types, `<Override PartName="/%s" ContentType="application/vnd.openxmlformats-officedocument.drawing+xml"/>`, drawing)
+			}
+			if len(commentsParts) > 0 && !bytes.Contains(data, []byte(`Extension="vml"`)) {
+				types.WriteString(`<Default Extension="vml" ContentType="application/vnd.openxmlformats-officedocument.vmlDrawing"/>`)
+			}
+			for _, comments := range commentsParts {
+				fmt.Fprintf(&types, `<Override PartName="/%s" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.comments+xml"/>`, comments)
+			}
+			data = insertBeforeClosingTag(data, "</Types>", types.String())
+		case file.Name == "xl/workbook.xml" && excel.file.Date1904:
+			data = bytes.Replace(data, []byte(`date1904="false"`), []byte(`date1904="true"`), 1)
+		case sheetParts[file.Name] != "":
+			data = insertBeforeClosingTag(data, "</worksheet>", sheetParts[file.Name])
+		case sheetRels[file.Name] != "":
+			data = insertBeforeClosingTag(data, "</Relationships>", sheetRels[file.Name])
+			delete(sheetRels, file.Name)
+		}
+		err = writePart(file.Name, data)
+		if err != nil {
+			return err
+		}
+	}
+	// Sheets without relationships have no rels part yet
+	for _, name := range slices.Sorted(maps.Keys(sheetRels)) {
+		addPart(name, []byte(xml.Header+`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`+sheetRels[name]+`</Relationships>`))
+	}
+	for _, name := range addedNames {
+		err = writePart(name, added[name])
+		if err != nil {
+			return err
+		}
+	}
+	return zipWriter.Close()
+}
+
+// Relationship IDs of the parts added to a sheet
+// chosen to not collide with the rId1, rId2, ... IDs used by tealeg/xlsx
+const (
+	drawingRelID    = "rIdDrawing1"
+	commentsRelID   = "rIdComments1"
+	vmlDrawingRelID = "rIdVmlDrawing1"
+)
+
+func readZipFile(file *zip.File) ([]byte, error) {
+	reader, err := file.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+	return io.ReadAll(reader)
+}
+
+func insertBeforeClosingTag(data []byte, closingTag, insert string) []byte {
+	pos := bytes.LastIndex(data, []byte(closingTag))
+	if pos < 0 {
+		return data
+	}
+	result := make([]byte, 0, len(data)+len(insert))
+	result = append(result, data[:pos]...)
+	result = append(result, insert...)
+	return append(result, data[pos:]...)
+}
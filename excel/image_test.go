@@ -0,0 +1,82 @@
+package excel
+
+import (
+	"archive/zip"
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	xlsx "github.com/tealeg/xlsx/v3"
+
+	"github.com/domonda/go-structtable"
+)
+
+type testLogo []byte
+
+func TestRendererImages(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 2, 2))
+	img.Set(0, 0, color.RGBA{R: 255, A: 255})
+	var pngData bytes.Buffer
+	require.NoError(t, png.Encode(&pngData, img))
+
+	type row struct {
+		Name string
+		Logo testLogo
+	}
+
+	renderer, err := NewRenderer("Sheet 1")
+	require.NoError(t, err, "NewRenderer")
+	renderer.RegisterImageType(reflect.TypeOf(testLogo(nil)))
+
+	err = structtable.Render(renderer, []row{{"A", pngData.Bytes()}, {"B", nil}}, true, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+	err = renderer.AddRowImage(-1, 1, pngData.Bytes(), "png")
+	require.NoError(t, err, "AddRowImage")
+	err = renderer.AddRowImage(0, 0, []byte("no image"), "")
+	assert.Error(t, err, "AddRowImage with invalid data")
+
+	result, err := renderer.Result()
+	require.NoError(t, err, "Result")
+
+	parts := zipParts(t, result)
+
+	assert.Equal(t, pngData.String(), parts["xl/media/image1.png"])
+	assert.Equal(t, pngData.String(), parts["xl/media/image2.png"])
+	assert.Contains(t, parts["xl/drawings/drawing1.xml"], "<xdr:from><xdr:col>1</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>1</xdr:row>")
+	assert.Contains(t, parts["xl/drawings/drawing1.xml"], "<xdr:from><xdr:col>1</xdr:col><xdr:colOff>0</xdr:colOff><xdr:row>2</xdr:row>")
+	assert.Contains(t, parts["xl/drawings/_rels/drawing1.xml.rels"], `Target="../media/image2.png"`)
+	assert.Contains(t, parts["xl/worksheets/sheet1.xml"], `<drawing r:id="rIdDrawing1"/></worksheet>`)
+	assert.Contains(t, parts["xl/worksheets/_rels/sheet1.xml.rels"], `Target="../drawings/drawing1.xml"`)
+	assert.Contains(t, parts["[Content_Types].xml"], `Extension="png"`)
+	assert.Contains(t, parts["[Content_Types].xml"], `PartName="/xl/drawings/drawing1.xml"`)
+
+	// The file with images must still be readable
+	file, err := xlsx.OpenBinary(result)
+	require.NoError(t, err, "OpenBinary")
+	cell, err := file.Sheets[0].Cell(1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "A", cell.Value)
+}
+
+// zipParts returns the contents of the files
+// of the zip archive data by their names
+func zipParts(t *testing.T, data []byte) map[string]string {
+	t.Helper()
+	zipReader, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err, "zip.NewReader")
+	parts := make(map[string]string)
+	for _, file := range zipReader.File {
+		reader, err := file.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(reader)
+		require.NoError(t, err)
+		parts[file.Name] = string(data)
+	}
+	return parts
+}
@@ -0,0 +1,117 @@
+package excel
+
+import (
+	"strconv"
+	"strings"
+
+	fs "github.com/ungerik/go-fs"
+
+	"github.com/domonda/go-errs"
+)
+
+// cellRange is a rectangle of cells
+// from the first to the last cell including
+type cellRange struct {
+	first, last cellPos
+}
+
+func (r *cellRange) numRows() int { return r.last.row - r.first.row + 1 }
+func (r *cellRange) numCols() int { return r.last.col - r.first.col + 1 }
+
+// NewReaderRange creates a new structtable.Reader that only reads
+// the cells within a1Range of the sheet sheetName in xlsxFile.
+// Row and column indices of the Reader are relative to the range.
+//
+// The range can be an A1 style range like "B5:F200" or "$B$5:$F$200",
+// a single cell like "B5", or the name of a defined name of the file
+// that refers to a range like "Sheet1!$B$5:$F$200".
+// If sheetName is "", then the sheet of the defined name
+// or the first sheet will be used.
+// An error is returned for malformed ranges
+// or ranges exceeding the bounds of the sheet.
+func NewReaderRange(xlsxFile fs.FileReader, sheetName, a1Range string) (*Reader, error) {
+	file, err := openFile(xlsxFile)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, definedName := range file.DefinedNames {
+		if !strings.EqualFold(definedName.Name, a1Range) {
+			continue
+		}
+		refSheet, ref, ok := strings.Cut(definedName.Data, "!")
+		if !ok {
+			return nil, errs.Errorf("defined name %q of excel file %s does not refer to a range of a sheet: %q", a1Range, xlsxFile, definedName.Data)
+		}
+		refSheet = strings.ReplaceAll(strings.Trim(refSheet, "'"), "''", "'")
+		if sheetName != "" && sheetName != refSheet {
+			return nil, errs.Errorf("defined name %q of excel file %s refers to sheet %q instead of %q", a1Range, xlsxFile, refSheet, sheetName)
+		}
+		sheetName, a1Range = refSheet, ref
+		break
+	}
+
+	cells, err := parseA1Range(a1Range)
+	if err != nil {
+		return nil, err
+	}
+
+	reader := &Reader{cellRange: &cells}
+	if sheetName != "" {
+		reader.sheet = file.Sheet[sheetName]
+		if reader.sheet == nil {
+			return nil, errs.Errorf("excel file %s does not have a sheet called %q", xlsxFile, sheetName)
+		}
+	} else {
+		reader.sheet = file.Sheets[0]
+	}
+	if cells.last.row >= reader.sheet.MaxRow || cells.last.col >= reader.sheet.MaxCol {
+		return nil, errs.Errorf("range %q exceeds the %d rows and %d columns of sheet %q", a1Range, reader.sheet.MaxRow, reader.sheet.MaxCol, reader.sheet.Name)
+	}
+	return reader, nil
+}
+
+// parseA1Range parses an A1 style range like "B5:F200"
+// or a single cell like "B5".
+// The first and last cell are normalized to be the
+// top-left and bottom-right cell of the range.
+func parseA1Range(a1Range string) (cellRange, error) {
+	firstRef, lastRef, isRange := strings.Cut(a1Range, ":")
+	first, err := parseA1Cell(firstRef)
+	if err != nil {
+		return cellRange{}, errs.Errorf("invalid range %q: %w", a1Range, err)
+	}
+	last := first
+	if isRange {
+		last, err = parseA1Cell(lastRef)
+		if err != nil {
+			return cellRange{}, errs.Errorf("invalid range %q: %w", a1Range, err)
+		}
+	}
+	return cellRange{
+		first: cellPos{row: min(first.row, last.row), col: min(first.col, last.col)},
+		last:  cellPos{row: max(first.row, last.row), col: max(first.col, last.col)},
+	}, nil
+}
+
+// parseA1Cell parses an A1 style cell reference like "B5" or "$B$5"
+// into zero based row and column indices.
+func parseA1Cell(ref string) (cellPos, error) {
+	s := strings.ToUpper(strings.ReplaceAll(ref, "$", ""))
+	numLetters := 0
+	for numLetters < len(s) && s[numLetters] >= 'A' && s[numLetters] <= 'Z' {
+		numLetters++
+	}
+	if numLetters == 0 || numLetters > 3 {
+		return cellPos{}, errs.Errorf("cell %q must begin with one to three column letters", ref)
+	}
+	row, err := strconv.Atoi(s[numLetters:])
+	if err != nil || row < 1 || s[numLetters] == '+' {
+		return cellPos{}, errs.Errorf("cell %q must end with a row number starting at 1", ref)
+	}
+	col := 0
+	for _, c := range s[:numLetters] {
+		col = col*26 + int(c-'A') + 1
+	}
+	return cellPos{row: row - 1, col: col - 1}, nil
+}
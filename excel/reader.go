@@ -8,28 +8,45 @@ import (
 	fs "github.com/ungerik/go-fs"
 
 	"github.com/domonda/go-errs"
+	"github.com/domonda/go-structtable"
 )
 
 type Reader struct {
+	// FillMergedCells propagates the value of a merged cell
+	// to all cells of its span instead of returning
+	// empty strings for all but the top-left cell.
+	FillMergedCells bool
+	// TrimTrailingEmpty makes NumRows and NumCols return
+	// EffectiveNumRows and EffectiveNumCols so that trailing
+	// empty rows and columns of the sheet are not read.
+	TrimTrailingEmpty bool
+	// RawValues makes the Reader return the raw stored values
+	// of cells, like the serial number of a date cell,
+	// instead of the values formatted with the number format
+	// of the cells as displayed by Excel.
+	// Useful for debugging the import of numeric cells.
+	RawValues bool
+
 	sheet *xlsx.Sheet
+	// merged maps the cells covered by merged cells
+	// to the value of the merged cell, built on first use
+	merged map[cellPos]string
+	// effectiveSize is the number of rows and columns
+	// up to the last non-empty cells, built on first use
+	effectiveSize *cellPos
+	// cellRange limits reading to a rectangle of the sheet if not nil
+	cellRange *cellRange
+}
+
+type cellPos struct {
+	row, col int
 }
 
 // NewReader creates a new structtable.Reader for the sheet sheetName in xlsxFile.
 // If sheetName is "", then the first sheet will be used.
 // Note: Reader only reads into string kind struct fields so far.
 func NewReader(xlsxFile fs.FileReader, sheetName string) (*Reader, error) {
-	fileReader, err := xlsxFile.OpenReadSeeker()
-	if err != nil {
-		return nil, err
-	}
-	defer fileReader.Close()
-
-	zipReader, err := zip.NewReader(fileReader, xlsxFile.Size())
-	if err != nil {
-		return nil, err
-	}
-
-	file, err := xlsx.ReadZipReader(zipReader)
+	file, err := openFile(xlsxFile)
 	if err != nil {
 		return nil, err
 	}
@@ -47,37 +64,246 @@ func NewReader(xlsxFile fs.FileReader, sheetName string) (*Reader, error) {
 	return reader, nil
 }
 
+// NewReaderByIndex creates a new structtable.Reader for the sheet
+// at the zero based index in xlsxFile.
+// Useful if the position of a sheet is known but not its localized name.
+func NewReaderByIndex(xlsxFile fs.FileReader, index int) (*Reader, error) {
+	file, err := openFile(xlsxFile)
+	if err != nil {
+		return nil, err
+	}
+	if index < 0 || index >= len(file.Sheets) {
+		return nil, errs.Errorf("sheet index %d out of bounds [0..%d) of excel file %s", index, len(file.Sheets), xlsxFile)
+	}
+	return &Reader{sheet: file.Sheets[index]}, nil
+}
+
+// SheetNames returns the names of the sheets in xlsxFile
+// in the order of their index.
+func SheetNames(xlsxFile fs.FileReader) ([]string, error) {
+	file, err := openFile(xlsxFile)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, len(file.Sheets))
+	for i, sheet := range file.Sheets {
+		names[i] = sheet.Name
+	}
+	return names, nil
+}
+
+// ReadAllSheets returns the strings of all rows
+// of every sheet in xlsxFile by sheet name.
+// The file is parsed only once for all sheets.
+func ReadAllSheets(xlsxFile fs.FileReader) (map[string][][]string, error) {
+	file, err := openFile(xlsxFile)
+	if err != nil {
+		return nil, err
+	}
+	sheets := make(map[string][][]string, len(file.Sheets))
+	for _, sheet := range file.Sheets {
+		rows, err := (&Reader{sheet: sheet}).ReadAllStrings()
+		if err != nil {
+			return nil, errs.Errorf("error reading sheet %q: %w", sheet.Name, err)
+		}
+		sheets[sheet.Name] = rows
+	}
+	return sheets, nil
+}
+
+// ReadSheetInto reads the sheet sheetName of xlsxFile
+// into the struct slice pointed to by structSlicePtr
+// and returns the first numHeaderRows rows as headerRows,
+// see NewReader and structtable.Read.
+func ReadSheetInto(xlsxFile fs.FileReader, sheetName string, structSlicePtr any, numHeaderRows int) (headerRows [][]string, err error) {
+	reader, err := NewReader(xlsxFile, sheetName)
+	if err != nil {
+		return nil, err
+	}
+	return structtable.Read(reader, structSlicePtr, numHeaderRows)
+}
+
+func openFile(xlsxFile fs.FileReader) (*xlsx.File, error) {
+	fileReader, err := xlsxFile.OpenReadSeeker()
+	if err != nil {
+		return nil, err
+	}
+	defer fileReader.Close()
+
+	zipReader, err := zip.NewReader(fileReader, xlsxFile.Size())
+	if err != nil {
+		return nil, err
+	}
+
+	return xlsx.ReadZipReader(zipReader)
+}
+
+// NumRows returns the number of rows of the sheet
+// or EffectiveNumRows if TrimTrailingEmpty is true.
+// If the Reader was created by NewReaderRange, then
+// the number of rows of the range is returned.
 func (r *Reader) NumRows() int {
+	if r.cellRange != nil {
+		return r.cellRange.numRows()
+	}
+	if r.TrimTrailingEmpty {
+		return r.EffectiveNumRows()
+	}
 	return r.sheet.MaxRow
 }
 
+// NumCols returns the number of columns of the sheet
+// or EffectiveNumCols if TrimTrailingEmpty is true.
+// If the Reader was created by NewReaderRange, then
+// the number of columns of the range is returned.
+func (r *Reader) NumCols() int {
+	if r.cellRange != nil {
+		return r.cellRange.numCols()
+	}
+	if r.TrimTrailingEmpty {
+		return r.EffectiveNumCols()
+	}
+	return r.sheet.MaxCol
+}
+
+// EffectiveNumRows returns the number of rows up to
+// the last row with a non-empty cell, excluding
+// trailing empty rows that are counted by the sheet.
+func (r *Reader) EffectiveNumRows() int {
+	return r.getEffectiveSize().row
+}
+
+// EffectiveNumCols returns the number of columns up to
+// the last column with a non-empty cell in any row.
+func (r *Reader) EffectiveNumCols() int {
+	return r.getEffectiveSize().col
+}
+
+func (r *Reader) getEffectiveSize() cellPos {
+	if r.effectiveSize != nil {
+		return *r.effectiveSize
+	}
+	var size cellPos
+	for rowIndex := r.sheet.MaxRow - 1; rowIndex >= 0; rowIndex-- {
+		row, err := r.sheet.Row(rowIndex)
+		if err != nil {
+			continue
+		}
+		for col := r.sheet.MaxCol - 1; col >= size.col; col-- {
+			if r.cellValue(row.GetCell(col)) != "" {
+				size.row = max(size.row, rowIndex+1)
+				size.col = col + 1
+				break
+			}
+		}
+	}
+	r.effectiveSize = &size
+	return size
+}
+
 func (r *Reader) ReadRowStrings(rowIndex int) ([]string, error) {
-	if rowIndex < 0 || rowIndex >= r.sheet.MaxRow {
+	if rowIndex < 0 || rowIndex >= r.NumRows() {
 		return nil, errs.Errorf("row index %d out of bounds", rowIndex)
 	}
 
-	row, err := r.sheet.Row(rowIndex)
+	sheetRow, sheetCol := r.sheetPos(rowIndex)
+	row, err := r.sheet.Row(sheetRow)
 	if err != nil {
 		return nil, err
 	}
-	strs := make([]string, r.sheet.MaxCol)
+	strs := make([]string, r.NumCols())
 	for col := range strs {
-		strs[col] = row.GetCell(col).String()
+		strs[col], err = r.cellString(row, sheetRow, sheetCol+col)
+		if err != nil {
+			return nil, err
+		}
 	}
 	return strs, nil
 }
 
+func (r *Reader) ReadAllStrings() ([][]string, error) {
+	return structtable.ReadAllRowStrings(r)
+}
+
 func (r *Reader) ReadRow(rowIndex int, destStruct reflect.Value) error {
-	if rowIndex < 0 || rowIndex >= r.sheet.MaxRow {
+	if rowIndex < 0 || rowIndex >= r.NumRows() {
 		return errs.Errorf("row index %d out of bounds", rowIndex)
 	}
 
-	row, err := r.sheet.Row(rowIndex)
+	sheetRow, sheetCol := r.sheetPos(rowIndex)
+	row, err := r.sheet.Row(sheetRow)
 	if err != nil {
 		return err
 	}
-	for col := 0; col < r.sheet.MaxCol && col < destStruct.NumField(); col++ {
-		destStruct.Field(col).SetString(row.GetCell(col).String())
+	for col := 0; col < r.NumCols() && col < destStruct.NumField(); col++ {
+		str, err := r.cellString(row, sheetRow, sheetCol+col)
+		if err != nil {
+			return err
+		}
+		destStruct.Field(col).SetString(str)
+	}
+	return nil
+}
+
+// sheetPos returns the sheet row index and the sheet column index
+// of the first column for the rowIndex of the Reader.
+func (r *Reader) sheetPos(rowIndex int) (sheetRow, sheetCol int) {
+	if r.cellRange == nil {
+		return rowIndex, 0
+	}
+	return r.cellRange.first.row + rowIndex, r.cellRange.first.col
+}
+
+func (r *Reader) cellString(row *xlsx.Row, rowIndex, col int) (string, error) {
+	if r.FillMergedCells {
+		if r.merged == nil {
+			err := r.collectMergedCells()
+			if err != nil {
+				return "", err
+			}
+		}
+		if str, ok := r.merged[cellPos{rowIndex, col}]; ok {
+			return str, nil
+		}
+	}
+	return r.cellValue(row.GetCell(col)), nil
+}
+
+// cellValue returns the value of cell formatted with
+// the number format of the cell, or the raw value
+// if RawValues is true or the cell could not be formatted.
+func (r *Reader) cellValue(cell *xlsx.Cell) string {
+	if r.RawValues {
+		return cell.Value
+	}
+	str, err := cell.FormattedValue()
+	if err != nil {
+		return cell.Value
+	}
+	return str
+}
+
+// collectMergedCells maps all cells covered by
+// merged cells of the sheet to the merged value.
+func (r *Reader) collectMergedCells() error {
+	r.merged = make(map[cellPos]string)
+	for rowIndex := 0; rowIndex < r.sheet.MaxRow; rowIndex++ {
+		row, err := r.sheet.Row(rowIndex)
+		if err != nil {
+			return err
+		}
+		for col := 0; col < r.sheet.MaxCol; col++ {
+			cell := row.GetCell(col)
+			if cell.HMerge == 0 && cell.VMerge == 0 {
+				continue
+			}
+			str := r.cellValue(cell)
+			for y := rowIndex; y <= rowIndex+cell.VMerge; y++ {
+				for x := col; x <= col+cell.HMerge; x++ {
+					r.merged[cellPos{y, x}] = str
+				}
+			}
+		}
 	}
 	return nil
 }
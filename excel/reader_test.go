@@ -0,0 +1,214 @@
+package excel
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	xlsx "github.com/tealeg/xlsx/v3"
+	fs "github.com/ungerik/go-fs"
+
+	"github.com/domonda/go-structtable"
+)
+
+func TestNewReaderByIndex(t *testing.T) {
+	type row struct{ Name string }
+
+	renderer, err := NewRenderer("Übersicht")
+	require.NoError(t, err, "NewRenderer")
+	require.NoError(t, renderer.AddSheet("Daten"))
+	err = structtable.Render(renderer, []row{{"A"}, {"B"}}, false, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+	data, err := renderer.Result()
+	require.NoError(t, err, "Result")
+	file := fs.NewMemFile("test.xlsx", data)
+
+	names, err := SheetNames(file)
+	require.NoError(t, err, "SheetNames")
+	assert.Equal(t, []string{"Übersicht", "Daten"}, names)
+
+	reader, err := NewReaderByIndex(file, 1)
+	require.NoError(t, err, "NewReaderByIndex")
+	assert.Equal(t, "Daten", reader.SheetName())
+	assert.Equal(t, 2, reader.NumRows())
+
+	_, err = NewReaderByIndex(file, 2)
+	assert.Error(t, err, "index out of bounds")
+	_, err = NewReaderByIndex(file, -1)
+	assert.Error(t, err, "negative index")
+}
+
+func TestReaderFillMergedCells(t *testing.T) {
+	xlsxFile := xlsx.NewFile()
+	sheet, err := xlsxFile.AddSheet("Sheet1")
+	require.NoError(t, err, "AddSheet")
+	header := sheet.AddRow()
+	quarter := header.AddCell()
+	quarter.SetString("Q1")
+	quarter.Merge(2, 0)
+	header.AddCell()
+	header.AddCell()
+	months := sheet.AddRow()
+	for _, month := range []string{"Jan", "Feb", "Mar"} {
+		months.AddCell().SetString(month)
+	}
+	var buf bytes.Buffer
+	require.NoError(t, xlsxFile.Write(&buf), "Write")
+	file := fs.NewMemFile("merged.xlsx", buf.Bytes())
+
+	reader, err := NewReader(file, "")
+	require.NoError(t, err, "NewReader")
+	row, err := reader.ReadRowStrings(0)
+	require.NoError(t, err, "ReadRowStrings")
+	assert.Equal(t, []string{"Q1", "", ""}, row, "merged cells not filled by default")
+
+	reader.FillMergedCells = true
+	rows, err := reader.ReadAllStrings()
+	require.NoError(t, err, "ReadAllStrings")
+	assert.Equal(t, [][]string{{"Q1", "Q1", "Q1"}, {"Jan", "Feb", "Mar"}}, rows)
+}
+
+func TestReaderTrimTrailingEmpty(t *testing.T) {
+	xlsxFile := xlsx.NewFile()
+	sheet, err := xlsxFile.AddSheet("Sheet1")
+	require.NoError(t, err, "AddSheet")
+	for _, values := range [][]string{{"A", "1", ""}, {"", "", ""}, {"B", "", ""}, {"", "", ""}, {"", "", ""}} {
+		row := sheet.AddRow()
+		for _, value := range values {
+			row.AddCell().SetString(value)
+		}
+	}
+	var buf bytes.Buffer
+	require.NoError(t, xlsxFile.Write(&buf), "Write")
+	file := fs.NewMemFile("trailing.xlsx", buf.Bytes())
+
+	reader, err := NewReader(file, "")
+	require.NoError(t, err, "NewReader")
+	assert.Equal(t, 5, reader.NumRows())
+	assert.Equal(t, 3, reader.NumCols())
+	assert.Equal(t, 3, reader.EffectiveNumRows())
+	assert.Equal(t, 2, reader.EffectiveNumCols())
+
+	reader.TrimTrailingEmpty = true
+	rows, err := reader.ReadAllStrings()
+	require.NoError(t, err, "ReadAllStrings")
+	assert.Equal(t, [][]string{{"A", "1"}, {"", ""}, {"B", ""}}, rows, "inner empty row kept")
+
+	type row struct{ Name, Value string }
+	var structs []row
+	_, err = structtable.Read(reader, &structs, 0)
+	require.NoError(t, err, "Read")
+	assert.Equal(t, []row{{"A", "1"}, {}, {"B", ""}}, structs)
+}
+
+func TestReadAllSheets(t *testing.T) {
+	type person struct{ Name, City string }
+	type product struct{ Title string }
+
+	renderer, err := NewRenderer("People")
+	require.NoError(t, err, "NewRenderer")
+	err = structtable.Render(renderer, []person{{"Alice", "Vienna"}}, true, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+	require.NoError(t, renderer.AddSheet("Products"))
+	err = structtable.Render(renderer, []product{{"Apple"}, {"Pear"}}, false, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+	data, err := renderer.Result()
+	require.NoError(t, err, "Result")
+	file := fs.NewMemFile("test.xlsx", data)
+
+	sheets, err := ReadAllSheets(file)
+	require.NoError(t, err, "ReadAllSheets")
+	assert.Equal(t, map[string][][]string{
+		"People":   {{"Name", "City"}, {"Alice", "Vienna"}},
+		"Products": {{"Apple"}, {"Pear"}},
+	}, sheets)
+
+	var people []person
+	header, err := ReadSheetInto(file, "People", &people, 1)
+	require.NoError(t, err, "ReadSheetInto")
+	assert.Equal(t, [][]string{{"Name", "City"}}, header)
+	assert.Equal(t, []person{{"Alice", "Vienna"}}, people)
+
+	_, err = ReadSheetInto(file, "Missing", &people, 0)
+	assert.Error(t, err, "missing sheet")
+}
+
+func TestNewReaderRange(t *testing.T) {
+	xlsxFile := xlsx.NewFile()
+	sheet, err := xlsxFile.AddSheet("Data")
+	require.NoError(t, err, "AddSheet")
+	for _, values := range [][]string{
+		{"Report", "", "", ""},
+		{"", "Name", "Value", ""},
+		{"", "A", "1", "note"},
+		{"", "B", "2", ""},
+		{"Total", "", "3", ""},
+	} {
+		row := sheet.AddRow()
+		for _, value := range values {
+			row.AddCell().SetString(value)
+		}
+	}
+	require.NoError(t, xlsxFile.AddDefinedName(xlsx.DefinedName{Name: "Table", Data: "Data!$B$2:$C$4"}), "AddDefinedName")
+	var buf bytes.Buffer
+	require.NoError(t, xlsxFile.Write(&buf), "Write")
+	file := fs.NewMemFile("range.xlsx", buf.Bytes())
+
+	for _, a1Range := range []string{"B2:C4", "$B$2:$C$4", "c4:b2", "Table"} {
+		reader, err := NewReaderRange(file, "", a1Range)
+		require.NoError(t, err, a1Range)
+		assert.Equal(t, 3, reader.NumRows(), a1Range)
+		assert.Equal(t, 2, reader.NumCols(), a1Range)
+		rows, err := reader.ReadAllStrings()
+		require.NoError(t, err, a1Range)
+		assert.Equal(t, [][]string{{"Name", "Value"}, {"A", "1"}, {"B", "2"}}, rows, a1Range)
+	}
+
+	reader, err := NewReaderRange(file, "Data", "B3:C4")
+	require.NoError(t, err, "NewReaderRange")
+	type row struct{ Name, Value string }
+	var structs []row
+	_, err = structtable.Read(reader, &structs, 0)
+	require.NoError(t, err, "Read")
+	assert.Equal(t, []row{{"A", "1"}, {"B", "2"}}, structs)
+
+	reader, err = NewReaderRange(file, "Data", "A5")
+	require.NoError(t, err, "single cell")
+	rows, err := reader.ReadAllStrings()
+	require.NoError(t, err, "ReadAllStrings")
+	assert.Equal(t, [][]string{{"Total"}}, rows)
+
+	for _, a1Range := range []string{"", "2B", "B0", "B2:", "B2:C", "ABCD1", "B2:E4", "A6"} {
+		_, err = NewReaderRange(file, "Data", a1Range)
+		assert.Error(t, err, a1Range)
+	}
+	_, err = NewReaderRange(file, "Other", "Table")
+	assert.Error(t, err, "defined name of other sheet")
+}
+
+func TestReaderFormattedValues(t *testing.T) {
+	xlsxFile := xlsx.NewFile()
+	sheet, err := xlsxFile.AddSheet("Sheet1")
+	require.NoError(t, err, "AddSheet")
+	row := sheet.AddRow()
+	row.AddCell().SetFloatWithFormat(45306, "yyyy-mm-dd")
+	row.AddCell().SetFloatWithFormat(1234.5, "0.00")
+	// Format not supported by xlsx falls back to the raw value
+	row.AddCell().SetFloatWithFormat(45306, "dd.mm.yyyy")
+	row.AddCell().SetString("Text")
+	var buf bytes.Buffer
+	require.NoError(t, xlsxFile.Write(&buf), "Write")
+	file := fs.NewMemFile("formatted.xlsx", buf.Bytes())
+
+	reader, err := NewReader(file, "")
+	require.NoError(t, err, "NewReader")
+	strs, err := reader.ReadRowStrings(0)
+	require.NoError(t, err, "ReadRowStrings")
+	assert.Equal(t, []string{"2024-01-15", "1234.50", "45306", "Text"}, strs, "formatted values by default")
+
+	reader.RawValues = true
+	strs, err = reader.ReadRowStrings(0)
+	require.NoError(t, err, "ReadRowStrings")
+	assert.Equal(t, []string{"45306", "1234.5", "45306", "Text"}, strs, "RawValues")
+}
@@ -4,16 +4,23 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"maps"
+	"math"
+	"math/big"
 	"reflect"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	xlsx "github.com/tealeg/xlsx/v3"
 	fs "github.com/ungerik/go-fs"
 
+	"github.com/domonda/go-structtable"
 	"github.com/domonda/go-types/date"
 	"github.com/domonda/go-types/money"
-	"github.com/domonda/go-types/nullable"
+	"github.com/domonda/go-types/strfmt"
 )
 
 const ContentType = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
@@ -24,8 +31,64 @@ type ExcelFormatConfig struct {
 	Date     string
 	Location *time.Location
 	Null     string
+	// ComplexValueFormatter formats values of types where
+	// structtable.IsComplexType returns true as string.
+	// If nil, such values are formatted with fmt.Sprint.
+	// NewRenderer sets it to structtable.FormatComplexValue.
+	ComplexValueFormatter structtable.ComplexValueFormatter
+	// EnumFormatters writes integer values of the mapped types
+	// as the names of their lookup table.
+	// Values missing in a lookup table are written as numbers.
+	EnumFormatters structtable.EnumFormatters
+	// NaNString is written as string for NaN values
+	// of float kind types because Excel has no NaN numbers.
+	NaNString string
+	// InfString is written as string for positive and negative
+	// infinite values of float kind types because Excel
+	// has no infinite numbers.
+	InfString string
+	// DatesAsText writes date.Date, date.NullableDate, and time.Time
+	// values as strings formatted with TextConfig instead of
+	// Excel serial dates to avoid any 1900/1904 epoch ambiguity
+	// when display fidelity matters more than date math.
+	DatesAsText bool
+	// TextConfig formats dates and times if DatesAsText is true
+	// and the elements of slices joined with SliceJoiner.
+	// If nil, then strfmt.NewFormatConfig is used.
+	TextConfig *strfmt.FormatConfig
+	// BoolTrue and BoolFalse are written as strings for
+	// bool values like the True and False of a text config,
+	// for example "Yes" and "No".
+	// If both are empty, then native TRUE/FALSE cells are written.
+	// The strings are not aligned like numbers, but left
+	// to the default text alignment of Excel.
+	BoolTrue  string
+	BoolFalse string
+	// SliceJoiner joins the elements of slices and arrays
+	// formatted with TextConfig as string cell if not empty,
+	// see structtable.FormatJoinedSlice.
+	// NewRenderer sets it to structtable.DefaultSliceJoiner.
+	// Slices with complex elements are formatted
+	// by the ComplexValueFormatter.
+	SliceJoiner string
+	// BlankZero leaves the cells of zero values of integer
+	// and float kind types like int, float64, or money.Amount empty,
+	// see structtable.IsZeroNumber. Integers with a name
+	// in EnumFormatters or a TypeCellWriter are not blanked
+	// because they are not written as numbers.
+	BlankZero bool
 }
 
+// Formula is an Excel formula like "=C{row}*D{row}"
+// written as live formula instead of a string value.
+// The placeholder {row} is replaced with
+// the 1-based Excel row number of the cell.
+type Formula string
+
+// FormulaRowPlaceholder is replaced in a Formula
+// with the 1-based Excel row number of the cell
+const FormulaRowPlaceholder = "{row}"
+
 type ExcelCellWriter interface {
 	WriteCell(cell *xlsx.Cell, val reflect.Value, config *ExcelFormatConfig) error
 }
@@ -36,13 +99,50 @@ func (f ExcelCellWriterFunc) WriteCell(cell *xlsx.Cell, val reflect.Value, confi
 	return f(cell, val, config)
 }
 
+// Renderer implements structtable.Renderer for Excel XLSX files.
+// Renderer is not safe for concurrent use,
+// call Reset to reuse it for another file.
 type Renderer struct {
-	file            *xlsx.File
-	currentSheet    *xlsx.Sheet
-	headerStyle     *xlsx.Style
-	cellStyle       *xlsx.Style
+	file         *xlsx.File
+	currentSheet *xlsx.Sheet
+	headerStyle  *xlsx.Style
+	cellStyle    *xlsx.Style
+	autoFilter   bool
+	tables       map[*xlsx.Sheet]*tableBounds
+	images       map[*xlsx.Sheet][]cellImage
+	comments     map[*xlsx.Sheet][]cellComment
+	sheetConfigs map[*xlsx.Sheet]*ExcelFormatConfig
+	// Config is used for sheets added without their own config
 	Config          ExcelFormatConfig
 	TypeCellWriters map[reflect.Type]ExcelCellWriter
+	// CommentAuthor is the author of cell comments,
+	// DefaultCommentAuthor is used if empty
+	CommentAuthor string
+	// SheetNameFunc returns the name for a sheet added with
+	// the requested name given the names of the existing sheets.
+	// The returned name must be a valid and unique sheet name.
+	// UniqueSheetName is used if nil.
+	SheetNameFunc func(name string, existing []string) string
+	// WrapHeaders wraps the text of header cells
+	// and increases the height of header rows
+	// to fit the estimated number of lines,
+	// so long titles don't make the columns too wide.
+	WrapHeaders bool
+}
+
+// tableBounds tracks the rendered rows and columns of a sheet
+type tableBounds struct {
+	firstRow     int
+	firstDataRow int
+	lastDataRow  int
+	lastRow      int
+	numCols      int
+	name         string
+	// dropdowns are the list options by column index
+	dropdowns map[int][]string
+	// numSheetValidations is the number of data validations
+	// of the sheet before the dropdowns were added or -1
+	numSheetValidations int
 }
 
 func NewRenderer(sheetName string) (*Renderer, error) {
@@ -53,12 +153,19 @@ func NewRenderer(sheetName string) (*Renderer, error) {
 	headerStyle.ApplyFont = true
 
 	excel := &Renderer{
-		file:        xlsx.NewFile(),
-		headerStyle: headerStyle,
+		file:         xlsx.NewFile(),
+		headerStyle:  headerStyle,
+		tables:       make(map[*xlsx.Sheet]*tableBounds),
+		images:       make(map[*xlsx.Sheet][]cellImage),
+		comments:     make(map[*xlsx.Sheet][]cellComment),
+		sheetConfigs: make(map[*xlsx.Sheet]*ExcelFormatConfig),
 		Config: ExcelFormatConfig{
 			Time:     "dd.mm.yyyy hh:mm:ss", // xlsx.DefaultDateTimeFormat
 			Date:     "dd.mm.yyyy",          // xlsx.DefaultDateFormat
 			Location: time.UTC,
+
+			ComplexValueFormatter: structtable.FormatComplexValue,
+			SliceJoiner:           structtable.DefaultSliceJoiner,
 		},
 		TypeCellWriters: map[reflect.Type]ExcelCellWriter{
 			reflect.TypeOf((*date.Date)(nil)).Elem():            ExcelCellWriterFunc(writeDateExcelCell),
@@ -67,12 +174,14 @@ func NewRenderer(sheetName string) (*Renderer, error) {
 			reflect.TypeOf((*time.Duration)(nil)).Elem():        ExcelCellWriterFunc(writeDurationExcelCell),
 			reflect.TypeOf((*money.Amount)(nil)).Elem():         ExcelCellWriterFunc(writeMoneyAmountExcelCell),
 			reflect.TypeOf((*money.CurrencyAmount)(nil)).Elem(): ExcelCellWriterFunc(writeMoneyCurrencyAmountExcelCell),
+			reflect.TypeOf((*big.Rat)(nil)).Elem():              ExcelCellWriterFunc(writeBigRatExcelCell),
+			reflect.TypeOf((*Formula)(nil)).Elem():              ExcelCellWriterFunc(writeFormulaExcelCell),
 		},
 	}
 
-	excel.file.Date1904 = true
+	excel.TypeCellWriters[reflect.TypeOf(Commented{})] = ExcelCellWriterFunc(excel.writeCommentedCell)
 
-	err := excel.AddSheet(sanitizeSheetName(sheetName))
+	err := excel.AddSheet(sheetName)
 	if err != nil {
 		return nil, err
 	}
@@ -80,15 +189,131 @@ func NewRenderer(sheetName string) (*Renderer, error) {
 	return excel, nil
 }
 
-func (excel *Renderer) AddSheet(name string) error {
-	newSheet, err := excel.file.AddSheet(sanitizeSheetName(name))
+// SetTypeRegistry adds the ExcelCell writers of registry
+// to TypeCellWriters, replacing existing writers of the same types.
+// An error is returned if a registered ExcelCell
+// does not implement ExcelCellWriter.
+func (excel *Renderer) SetTypeRegistry(registry *structtable.TypeRegistry) error {
+	writers := make(map[reflect.Type]ExcelCellWriter)
+	for t, registration := range registry.Registrations() {
+		if registration.ExcelCell == nil {
+			continue
+		}
+		writer, ok := registration.ExcelCell.(ExcelCellWriter)
+		if !ok {
+			return fmt.Errorf("ExcelCell %T registered for type %s does not implement excel.ExcelCellWriter", registration.ExcelCell, t)
+		}
+		writers[t] = writer
+	}
+	maps.Copy(excel.TypeCellWriters, writers)
+	return nil
+}
+
+// SetDate1904 sets if the file uses the 1904 date system
+// where serial dates count the days since 1904-01-01
+// instead of the default 1900 date system.
+// Must be called before rendering any dates
+// because their serial numbers depend on it.
+func (excel *Renderer) SetDate1904(date1904 bool) {
+	excel.file.Date1904 = date1904
+}
+
+// Date1904 returns if the file uses the 1904 date system.
+func (excel *Renderer) Date1904() bool {
+	return excel.file.Date1904
+}
+
+// RenderWithConfig renders like structtable.Render but with the passed
+// config instead of the config of the current sheet for dates, times,
+// locations and null values.
+// The original config of the sheet is restored after rendering.
+// A renderer must never be used concurrently.
+func RenderWithConfig(renderer *Renderer, structSlice any, renderTitleRow bool, columnMapper structtable.ColumnMapper, config ExcelFormatConfig) error {
+	current := renderer.config()
+	original := *current
+	*current = config
+	defer func() { *current = original }()
+
+	return structtable.Render(renderer, structSlice, renderTitleRow, columnMapper)
+}
+
+// Reset replaces the rendered file with a new one
+// containing empty sheets with the same names
+// so that the Renderer can be reused for another file.
+// Config, the configs and column dropdowns of the sheets,
+// and TypeCellWriters are kept.
+func (excel *Renderer) Reset() {
+	oldFile, oldSheet := excel.file, excel.currentSheet
+	var currentSheet *xlsx.Sheet
+	excel.file = xlsx.NewFile()
+	excel.file.Date1904 = oldFile.Date1904
+	excel.images = make(map[*xlsx.Sheet][]cellImage)
+	excel.comments = make(map[*xlsx.Sheet][]cellComment)
+	oldTables := excel.tables
+	excel.tables = make(map[*xlsx.Sheet]*tableBounds)
+	oldSheetConfigs := excel.sheetConfigs
+	excel.sheetConfigs = make(map[*xlsx.Sheet]*ExcelFormatConfig)
+	for _, sheet := range oldFile.Sheets {
+		// Names of existing sheets are already valid and unique
+		newSheet, err := excel.file.AddSheet(sheet.Name)
+		if err != nil {
+			panic(fmt.Sprintf("can't add existing sheet name %q to new file: %s", sheet.Name, err))
+		}
+		excel.currentSheet = newSheet
+		if sheet == oldSheet {
+			currentSheet = newSheet
+		}
+		if config, ok := oldSheetConfigs[sheet]; ok {
+			excel.sheetConfigs[newSheet] = config
+		}
+		if table, ok := oldTables[sheet]; ok && len(table.dropdowns) > 0 {
+			excel.currentTable().dropdowns = table.dropdowns
+		}
+	}
+	excel.currentSheet = currentSheet
+}
+
+// AddSheet adds a sheet with name and makes it the current sheet.
+// The name is passed through SheetNameFunc or UniqueSheetName
+// to get a valid name that differs from the existing sheets,
+// see CurrentSheetName for the name of the added sheet.
+// An optional config is used for the cells of the sheet
+// instead of the Config of the renderer.
+func (excel *Renderer) AddSheet(name string, config ...ExcelFormatConfig) error {
+	existing := make([]string, len(excel.file.Sheets))
+	for i, sheet := range excel.file.Sheets {
+		existing[i] = sheet.Name
+	}
+	nameFunc := excel.SheetNameFunc
+	if nameFunc == nil {
+		nameFunc = UniqueSheetName
+	}
+	newSheet, err := excel.file.AddSheet(nameFunc(name, existing))
 	if err != nil {
 		return err
 	}
 	excel.currentSheet = newSheet
+	if len(config) > 0 {
+		sheetConfig := config[0]
+		excel.sheetConfigs[newSheet] = &sheetConfig
+	}
 	return nil
 }
 
+// config returns the config of the current sheet
+// or the Config of the renderer if the sheet has none.
+func (excel *Renderer) config() *ExcelFormatConfig {
+	if config, ok := excel.sheetConfigs[excel.currentSheet]; ok {
+		return config
+	}
+	return &excel.Config
+}
+
+// CurrentSheetName returns the name of the current sheet
+func (excel *Renderer) CurrentSheetName() string {
+	return excel.currentSheet.Name
+}
+
 func (excel *Renderer) SetCurrentSheet(name string) error {
 	for _, sheet := range excel.file.Sheets {
 		if sheet.Name == name {
@@ -99,13 +324,297 @@ func (excel *Renderer) SetCurrentSheet(name string) error {
 	return fmt.Errorf("sheet with name '%s' not found", name)
 }
 
+// CurrentRowCount returns the number of rows of the current sheet
+// including rows that existed before the sheet was made current.
+func (excel *Renderer) CurrentRowCount() int {
+	return excel.currentSheet.MaxRow
+}
+
+// AddBlankRows adds n empty rows to the current sheet,
+// for example to leave a gap between multiple tables.
+func (excel *Renderer) AddBlankRows(n int) {
+	for i := 0; i < n; i++ {
+		excel.currentSheet.AddRow()
+	}
+}
+
+// RenderTitleText adds a row with text as bold label
+// to the current sheet, for example to label one of multiple
+// tables stacked vertically on the same sheet.
+// The label cell is merged over the columns
+// that have been rendered to the sheet so far.
+func (excel *Renderer) RenderTitleText(text string) {
+	excel.addMergedTextRow(text, newTitleStyle())
+}
+
+// RenderTextRow adds a row with text in italics merged over
+// the columns that have been rendered to the sheet so far.
+// Implements structtable.TextRowRenderer.
+func (excel *Renderer) RenderTextRow(text string) error {
+	style := xlsx.NewStyle()
+	style.Font.Italic = true
+	style.ApplyFont = true
+	excel.addMergedTextRow(text, style)
+	return nil
+}
+
+func (excel *Renderer) addMergedTextRow(text string, style *xlsx.Style) {
+	row := excel.currentSheet.AddRow()
+	cell := row.AddCell()
+	cell.SetStyle(style)
+	cell.SetString(text)
+	if numCols := excel.currentSheet.MaxCol; numCols > 1 {
+		cell.Merge(numCols-1, 0)
+	}
+}
+
+func newTitleStyle() *xlsx.Style {
+	style := xlsx.NewStyle()
+	style.Font.Bold = true
+	style.Font.Size = 12
+	style.Font.Name = "Liberation Sans"
+	style.ApplyFont = true
+	return style
+}
+
+// EnableAutoFilter enables auto-filter dropdowns over the header row
+// and the data rows of every sheet written by Result,
+// WriteResultTo, or WriteResultFile.
+func (excel *Renderer) EnableAutoFilter() *Renderer {
+	excel.autoFilter = true
+	return excel
+}
+
+// SetTableName registers the range of the header and data rows
+// rendered to the current sheet under the passed name
+// as defined name of the workbook, usable for references like
+// =SUM(INDEX(name,0,3)) in formulas.
+func (excel *Renderer) SetTableName(name string) {
+	excel.currentTable().name = name
+}
+
+// SetColumnDropdown constrains the values of the data rows
+// of the column with the index col in the current sheet
+// to the passed options using an Excel data validation
+// that shows a dropdown list of the options.
+// Passing no options removes the dropdown.
+// The joined options must not be longer than
+// 255 characters and the options must not contain
+// commas or double quotes because Excel stores them
+// as comma separated list in a quoted string,
+// else Result returns an error.
+func (excel *Renderer) SetColumnDropdown(col int, options []string) {
+	table := excel.currentTable()
+	if len(options) == 0 {
+		delete(table.dropdowns, col)
+		return
+	}
+	if table.dropdowns == nil {
+		table.dropdowns = make(map[int][]string)
+	}
+	table.dropdowns[col] = options
+}
+
+func (excel *Renderer) currentTable() *tableBounds {
+	table := excel.tables[excel.currentSheet]
+	if table == nil {
+		table = &tableBounds{firstRow: -1, firstDataRow: -1, lastDataRow: -1, lastRow: -1, numSheetValidations: -1}
+		excel.tables[excel.currentSheet] = table
+	}
+	return table
+}
+
+// trackRow updates the table bounds of the current sheet
+// with the last added row.
+func (excel *Renderer) trackRow(numCols int) {
+	table := excel.currentTable()
+	rowIndex := excel.currentSheet.MaxRow - 1
+	if table.firstRow < 0 {
+		table.firstRow = rowIndex
+	}
+	table.lastRow = rowIndex
+	if numCols > table.numCols {
+		table.numCols = numCols
+	}
+}
+
+// applyTables sets the auto-filters and defined names
+// of the tracked table bounds in the order of the sheets.
+// Defined names of earlier calls are updated to the current
+// bounds because rows can be rendered after a Result.
+func (excel *Renderer) applyTables() error {
+	for _, sheet := range excel.file.Sheets {
+		table := excel.tables[sheet]
+		if table == nil || table.firstRow < 0 || table.numCols == 0 {
+			continue
+		}
+		topLeft := xlsx.GetCellIDStringFromCoords(0, table.firstRow)
+		bottomRight := xlsx.GetCellIDStringFromCoords(table.numCols-1, table.lastRow)
+		if excel.autoFilter {
+			sheet.AutoFilter = &xlsx.AutoFilter{
+				TopLeftCell:     topLeft,
+				BottomRightCell: bottomRight,
+			}
+		}
+		if table.name != "" {
+			err := excel.defineName(table.name, fmt.Sprintf(
+				"'%s'!%s:%s",
+				strings.ReplaceAll(sheet.Name, "'", "''"),
+				xlsx.GetCellIDStringFromCoordsWithFixed(0, table.firstRow, true, true),
+				xlsx.GetCellIDStringFromCoordsWithFixed(table.numCols-1, table.lastRow, true, true),
+			))
+			if err != nil {
+				return err
+			}
+		}
+		err := applyDropdowns(sheet, table)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// defineName adds a defined name to the file
+// or updates the data of an existing one with the same name.
+func (excel *Renderer) defineName(name, data string) error {
+	for _, definedName := range excel.file.DefinedNames {
+		if definedName.Name == name {
+			definedName.Data = data
+			return nil
+		}
+	}
+	return excel.file.AddDefinedName(xlsx.DefinedName{Name: name, Data: data})
+}
+
+// applyDropdowns replaces the data validations
+// added for the dropdowns of table to sheet.
+func applyDropdowns(sheet *xlsx.Sheet, table *tableBounds) error {
+	if table.numSheetValidations < 0 {
+		if len(table.dropdowns) == 0 {
+			return nil
+		}
+		table.numSheetValidations = len(sheet.DataValidations)
+	}
+	sheet.DataValidations = sheet.DataValidations[:table.numSheetValidations]
+	if table.firstDataRow < 0 {
+		return nil
+	}
+	for _, col := range slices.Sorted(maps.Keys(table.dropdowns)) {
+		for _, option := range table.dropdowns[col] {
+			if strings.ContainsAny(option, `,"`) {
+				return fmt.Errorf("dropdown option %q of column %d contains a comma or double quote", option, col)
+			}
+		}
+		validation := xlsx.NewDataValidation(table.firstDataRow, col, table.lastDataRow, col, true)
+		err := validation.SetDropList(table.dropdowns[col])
+		if err != nil {
+			return fmt.Errorf("dropdown of column %d: %w", col, err)
+		}
+		sheet.AddDataValidation(validation)
+	}
+	return nil
+}
+
 func (excel *Renderer) RenderHeaderRow(columnTitles []string) error {
 	row := excel.currentSheet.AddRow()
+	excel.trackRow(len(columnTitles))
+	style := excel.headerStyle
+	if excel.WrapHeaders {
+		style = newWrapStyle(excel.headerStyle)
+	}
 	for _, title := range columnTitles {
 		cell := row.AddCell()
-		cell.SetStyle(excel.headerStyle)
+		cell.SetStyle(style)
 		cell.SetString(title)
 	}
+	if excel.WrapHeaders {
+		excel.setWrappedRowHeight(row, columnTitles)
+	}
+	return nil
+}
+
+// headerLineHeight is the height in points
+// of a line of text in the header font
+const headerLineHeight = 12.75
+
+// newWrapStyle returns a copy of style with wrapped text
+// aligned to the top of the cell.
+func newWrapStyle(style *xlsx.Style) *xlsx.Style {
+	wrapStyle := xlsx.NewStyle()
+	wrapStyle.Font = style.Font
+	wrapStyle.ApplyFont = style.ApplyFont
+	wrapStyle.Alignment = style.Alignment
+	wrapStyle.Alignment.WrapText = true
+	wrapStyle.Alignment.Vertical = "top"
+	wrapStyle.ApplyAlignment = true
+	return wrapStyle
+}
+
+// setWrappedRowHeight sets the height of row to fit the number
+// of lines the longest wrapped title is estimated to need
+// within the width of its column.
+func (excel *Renderer) setWrappedRowHeight(row *xlsx.Row, titles []string) {
+	numLines := 1
+	for i, title := range titles {
+		width := xlsx.ColWidth
+		if cols := excel.currentSheet.Cols; cols != nil {
+			// Columns are 1-based in the ColStore
+			if col := cols.FindColByIndex(i + 1); col != nil && col.Width != nil {
+				width = *col.Width
+			}
+		}
+		chars := max(int(width), 1)
+		lines := 0
+		for _, line := range strings.Split(title, "\n") {
+			lines += max((utf8.RuneCountInString(line)+chars-1)/chars, 1)
+		}
+		numLines = max(numLines, lines)
+	}
+	if numLines > 1 {
+		row.SetHeight(float64(numLines) * headerLineHeight)
+	}
+}
+
+// HeaderGroup is the Title of a group of
+// Span columns rendered by RenderGroupedHeader.
+type HeaderGroup struct {
+	Title string
+	Span  int
+}
+
+// RenderGroupedHeader adds a row with the title of every group
+// in a bold and centered cell merged over the Span columns of the group.
+// Call it before rendering the header row with the column titles
+// of the groups to get a two-level header.
+// Like RenderTitleText the row is not part of the auto-filter
+// and table name range of the sheet.
+func (excel *Renderer) RenderGroupedHeader(groups []HeaderGroup) error {
+	for _, group := range groups {
+		if group.Span < 1 {
+			return fmt.Errorf("span %d of header group %q is less than one", group.Span, group.Title)
+		}
+	}
+
+	style := xlsx.NewStyle()
+	style.Font = excel.headerStyle.Font
+	style.ApplyFont = true
+	style.Alignment.Horizontal = "center"
+	style.ApplyAlignment = true
+
+	row := excel.currentSheet.AddRow()
+	for _, group := range groups {
+		cell := row.AddCell()
+		cell.SetStyle(style)
+		cell.SetString(group.Title)
+		if group.Span > 1 {
+			cell.Merge(group.Span-1, 0)
+			// Add the cells covered by the merged cell
+			for i := 1; i < group.Span; i++ {
+				row.AddCell().SetStyle(style)
+			}
+		}
+	}
 	return nil
 }
 
@@ -130,90 +639,190 @@ func DerefValueAndType(val interface{}) (reflect.Value, reflect.Type) {
 
 func (excel *Renderer) RenderRow(columnValues []reflect.Value) error {
 	row := excel.currentSheet.AddRow()
+	excel.trackRow(len(columnValues))
+	if table := excel.currentTable(); table.firstDataRow < 0 {
+		table.firstDataRow = table.lastRow
+		table.lastDataRow = table.lastRow
+	} else {
+		table.lastDataRow = table.lastRow
+	}
 	for _, val := range columnValues {
 		cell := row.AddCell()
 		cell.SetStyle(excel.cellStyle)
-
-		derefVal := val
-		for derefVal.Kind() == reflect.Ptr && !derefVal.IsNil() {
-			derefVal = derefVal.Elem()
+		err := excel.writeCell(cell, val)
+		if err != nil {
+			return err
 		}
-		derefType := derefVal.Type()
+	}
+	return nil
+}
 
-		if w, ok := excel.TypeCellWriters[derefType]; ok && derefVal.IsValid() {
-			// derefVal.IsValid() returns false for dereferenced nil pointer
-			// so the following will only be called for non nil pointers:
-			err := w.WriteCell(cell, derefVal, &excel.Config)
-			if err != nil {
-				return err
-			}
-			continue
+// RenderFooterRow renders the passed values as bold footer row.
+// Invalid reflect.Value elements result in empty cells.
+// RenderFooterRow renders columnValues as bold row.
+// The row is not tracked as part of the table bounds,
+// so auto-filters and defined names of the table
+// don't include footer rows like totals.
+func (excel *Renderer) RenderFooterRow(columnValues []reflect.Value) error {
+	row := excel.currentSheet.AddRow()
+	for _, val := range columnValues {
+		cell := row.AddCell()
+		cell.SetStyle(newFooterStyle())
+		err := excel.writeCell(cell, val)
+		if err != nil {
+			return err
 		}
+	}
+	return nil
+}
 
-		if nullable.ReflectIsNull(val) {
-			if excel.Config.Null != "" {
-				cell.SetString(excel.Config.Null)
-			}
-			continue
+func newFooterStyle() *xlsx.Style {
+	style := xlsx.NewStyle()
+	style.Font.Bold = true
+	style.ApplyFont = true
+	return style
+}
+
+func (excel *Renderer) writeCell(cell *xlsx.Cell, val reflect.Value) error {
+	config := excel.config()
+	if structtable.IsNull(val) {
+		if config.Null != "" {
+			cell.SetString(config.Null)
 		}
+		return nil
+	}
 
-		switch derefType.Kind() {
-		case reflect.Bool:
-			cell.SetBool(derefVal.Bool())
-			continue
+	derefVal := val
+	for derefVal.Kind() == reflect.Ptr && !derefVal.IsNil() {
+		derefVal = derefVal.Elem()
+	}
+	derefType := derefVal.Type()
+
+	if derefVal.CanFloat() {
+		// Checked before TypeCellWriters because
+		// non-finite numbers result in invalid cells
+		switch f := derefVal.Float(); {
+		case math.IsNaN(f):
+			cell.SetString(config.NaNString)
+			return nil
+		case math.IsInf(f, 0):
+			cell.SetString(config.InfString)
+			return nil
+		}
+	}
 
-		case reflect.String:
-			cell.SetString(derefVal.String())
-			continue
+	if config.BlankZero && excel.isBlankZero(derefVal, config) {
+		return nil
+	}
 
-		case reflect.Float32, reflect.Float64:
-			cell.SetFloat(derefVal.Float())
-			cell.GetStyle().Alignment.Horizontal = "right"
-			cell.GetStyle().ApplyAlignment = true
-			continue
+	if w, ok := excel.TypeCellWriters[derefType]; ok {
+		return w.WriteCell(cell, derefVal, config)
+	}
 
-		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
-			cell.SetInt64(derefVal.Int())
-			cell.GetStyle().Alignment.Horizontal = "right"
-			cell.GetStyle().ApplyAlignment = true
-			continue
+	if name, ok := config.EnumFormatters.Format(derefVal); ok {
+		cell.SetString(name)
+		return nil
+	}
 
-		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
-			cell.SetInt64(int64(derefVal.Uint()))
-			cell.GetStyle().Alignment.Horizontal = "right"
-			cell.GetStyle().ApplyAlignment = true
-			continue
+	dynamicVal := derefVal
+	if dynamicVal.Kind() == reflect.Interface {
+		dynamicVal = dynamicVal.Elem()
+	}
+	if config.SliceJoiner != "" && structtable.IsComplexType(dynamicVal.Type()) {
+		if str, ok := structtable.FormatJoinedSlice(dynamicVal, config.SliceJoiner, config.textConfig()); ok {
+			cell.SetString(str)
+			return nil
 		}
+	}
+	if config.ComplexValueFormatter != nil && structtable.IsComplexType(dynamicVal.Type()) {
+		cell.SetString(config.ComplexValueFormatter(dynamicVal))
+		return nil
+	}
 
-		if s, ok := val.Interface().(fmt.Stringer); ok {
-			cell.SetString(s.String())
-			continue
-		}
-		if val.CanAddr() {
-			if s, ok := val.Addr().Interface().(fmt.Stringer); ok {
-				cell.SetString(s.String())
-				continue
-			}
-		}
-		if s, ok := derefVal.Interface().(fmt.Stringer); ok {
-			cell.SetString(s.String())
-			continue
+	switch derefType.Kind() {
+	case reflect.Bool:
+		switch {
+		case config.BoolTrue == "" && config.BoolFalse == "":
+			cell.SetBool(derefVal.Bool())
+		case derefVal.Bool():
+			cell.SetString(config.BoolTrue)
+		default:
+			cell.SetString(config.BoolFalse)
 		}
+		return nil
 
-		switch x := derefVal.Interface().(type) {
-		case []byte:
-			cell.SetString(string(x))
-			continue
+	case reflect.String:
+		cell.SetString(derefVal.String())
+		return nil
+
+	case reflect.Float32, reflect.Float64:
+		cell.SetFloat(derefVal.Float())
+		cell.GetStyle().Alignment.Horizontal = "right"
+		cell.GetStyle().ApplyAlignment = true
+		return nil
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		cell.SetInt64(derefVal.Int())
+		cell.GetStyle().Alignment.Horizontal = "right"
+		cell.GetStyle().ApplyAlignment = true
+		return nil
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		cell.SetInt64(int64(derefVal.Uint()))
+		cell.GetStyle().Alignment.Horizontal = "right"
+		cell.GetStyle().ApplyAlignment = true
+		return nil
+	}
+
+	if s, ok := val.Interface().(fmt.Stringer); ok {
+		cell.SetString(s.String())
+		return nil
+	}
+	if val.CanAddr() {
+		if s, ok := val.Addr().Interface().(fmt.Stringer); ok {
+			cell.SetString(s.String())
+			return nil
 		}
+	}
+	if s, ok := derefVal.Interface().(fmt.Stringer); ok {
+		cell.SetString(s.String())
+		return nil
+	}
 
-		cell.SetString(fmt.Sprint(val.Interface()))
+	switch x := derefVal.Interface().(type) {
+	case []byte:
+		cell.SetString(string(x))
+		return nil
 	}
+
+	cell.SetString(fmt.Sprint(val.Interface()))
 	return nil
 }
 
+// isBlankZero returns if val is a zero number
+// that has to be written as empty cell for BlankZero
+func (excel *Renderer) isBlankZero(val reflect.Value, config *ExcelFormatConfig) bool {
+	if !structtable.IsZeroNumber(val) {
+		return false
+	}
+	if val.CanInt() || val.CanUint() {
+		if _, ok := excel.TypeCellWriters[val.Type()]; ok {
+			return false
+		}
+		if _, ok := config.EnumFormatters.Format(val); ok {
+			return false
+		}
+	}
+	return true
+}
+
 func (excel *Renderer) Result() ([]byte, error) {
+	err := excel.applyTables()
+	if err != nil {
+		return nil, err
+	}
 	buf := bytes.NewBuffer(nil)
-	err := excel.file.Write(buf)
+	err = excel.writeFile(buf)
 	if err != nil {
 		return nil, err
 	}
@@ -221,7 +830,11 @@ func (excel *Renderer) Result() ([]byte, error) {
 }
 
 func (excel *Renderer) WriteResultTo(writer io.Writer) error {
-	return excel.file.Write(writer)
+	err := excel.applyTables()
+	if err != nil {
+		return err
+	}
+	return excel.writeFile(writer)
 }
 
 func (excel *Renderer) WriteResultFile(file fs.File, perm ...fs.Permissions) error {
@@ -231,7 +844,7 @@ func (excel *Renderer) WriteResultFile(file fs.File, perm ...fs.Permissions) err
 	}
 	defer writer.Close()
 
-	return excel.file.Write(writer)
+	return excel.WriteResultTo(writer)
 }
 
 func (*Renderer) MIMEType() string {
@@ -240,43 +853,62 @@ func (*Renderer) MIMEType() string {
 
 func writeDateExcelCell(cell *xlsx.Cell, val reflect.Value, config *ExcelFormatConfig) error {
 	if d := val.Interface().(date.Date); !d.IsZero() {
-		cell.SetDateWithOptions(
-			d.MidnightInLocation(config.Location),
-			xlsx.DateTimeOptions{
-				Location:        config.Location,
-				ExcelTimeFormat: config.Date,
-			},
-		)
+		if config.DatesAsText {
+			cell.SetString(formatDateText(val, config))
+			return nil
+		}
+		setExcelDate(cell, d.MidnightInLocation(config.Location), config.Location, config.Date)
 	}
 	return nil
 }
 
 func writeNullableDateExcelCell(cell *xlsx.Cell, val reflect.Value, config *ExcelFormatConfig) error {
 	if d := val.Interface().(date.NullableDate); !d.IsZero() {
-		cell.SetDateWithOptions(
-			d.MidnightInLocation(config.Location).Time,
-			xlsx.DateTimeOptions{
-				Location:        config.Location,
-				ExcelTimeFormat: config.Date,
-			},
-		)
+		if config.DatesAsText {
+			cell.SetString(formatDateText(val, config))
+			return nil
+		}
+		setExcelDate(cell, d.MidnightInLocation(config.Location).Time, config.Location, config.Date)
 	}
 	return nil
 }
 
 func writeTimeExcelCell(cell *xlsx.Cell, val reflect.Value, config *ExcelFormatConfig) error {
 	if t := val.Interface().(time.Time); !t.IsZero() {
-		cell.SetDateWithOptions(
-			t,
-			xlsx.DateTimeOptions{
-				Location:        t.Location(),
-				ExcelTimeFormat: config.Time,
-			},
-		)
+		if config.DatesAsText {
+			cell.SetString(formatDateText(val, config))
+			return nil
+		}
+		setExcelDate(cell, t, t.Location(), config.Time)
 	}
 	return nil
 }
 
+// setExcelDate sets t shown in loc as serial date with format
+// using the date system of the cell's file.
+// Unlike xlsx.Cell.SetDateWithOptions it respects
+// the Date1904 setting of the file for new cells.
+func setExcelDate(cell *xlsx.Cell, t time.Time, loc *time.Location, format string) {
+	_, offset := t.In(loc).Zone()
+	t = time.Unix(t.Unix()+int64(offset), int64(t.Nanosecond())).UTC()
+	date1904 := cell.Row != nil && cell.Row.Sheet != nil && cell.Row.Sheet.File != nil && cell.Row.Sheet.File.Date1904
+	cell.SetDateTimeWithFormat(xlsx.TimeToExcelTime(t, date1904), format)
+}
+
+// formatDateText formats the date or time val
+// with the TextConfig of config.
+func formatDateText(val reflect.Value, config *ExcelFormatConfig) string {
+	return strfmt.FormatValue(val, config.textConfig())
+}
+
+// textConfig returns the TextConfig or strfmt.NewFormatConfig if nil
+func (config *ExcelFormatConfig) textConfig() *strfmt.FormatConfig {
+	if config.TextConfig == nil {
+		return strfmt.NewFormatConfig()
+	}
+	return config.TextConfig
+}
+
 func writeDurationExcelCell(cell *xlsx.Cell, val reflect.Value, config *ExcelFormatConfig) error {
 	duration := val.Interface().(time.Duration)
 	excel1904Epoc := time.Date(1904, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -302,6 +934,58 @@ func writeMoneyCurrencyAmountExcelCell(cell *xlsx.Cell, val reflect.Value, confi
 	return nil
 }
 
+// writeBigRatExcelCell writes the exact decimal representation
+// of a big.Rat as numeric cell value without converting it to float64.
+func writeBigRatExcelCell(cell *xlsx.Cell, val reflect.Value, config *ExcelFormatConfig) error {
+	cell.SetNumeric(structtable.FormatDecimal(structtable.BigRatValue(val), 0, '.', -1))
+	return nil
+}
+
+// writeFormulaExcelCell writes a Formula with the
+// FormulaRowPlaceholder replaced by the row number of the cell.
+func writeFormulaExcelCell(cell *xlsx.Cell, val reflect.Value, config *ExcelFormatConfig) error {
+	_, row := cell.GetCoordinates()
+	formula := strings.TrimPrefix(val.String(), "=")
+	formula = strings.ReplaceAll(formula, FormulaRowPlaceholder, strconv.Itoa(row+1))
+	cell.SetFormula(formula)
+	return nil
+}
+
+// UniqueSheetName returns name sanitized to a valid sheet name.
+// If the sanitized name equals one of the existing names
+// (case insensitive like Excel compares sheet names)
+// a numeric suffix like " (2)" is appended,
+// truncating the name to stay within 31 characters.
+func UniqueSheetName(name string, existing []string) string {
+	name = sanitizeSheetName(name)
+	if !containsSheetName(existing, name) {
+		return name
+	}
+	base := []rune(name)
+	for n := 2; ; n++ {
+		suffix := fmt.Sprintf(" (%d)", n)
+		if maxBase := maxSheetNameLen - len(suffix); len(base) > maxBase {
+			base = base[:maxBase]
+		}
+		unique := strings.TrimRight(string(base), " …") + suffix
+		if !containsSheetName(existing, unique) {
+			return unique
+		}
+	}
+}
+
+func containsSheetName(names []string, name string) bool {
+	for _, n := range names {
+		if strings.EqualFold(n, name) {
+			return true
+		}
+	}
+	return false
+}
+
+// maxSheetNameLen is the maximum number of runes of an Excel sheet name
+const maxSheetNameLen = 31
+
 func sanitizeSheetName(name string) string {
 	name = strings.TrimSpace(name)
 	if name == "" {
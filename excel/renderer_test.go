@@ -1,14 +1,22 @@
 package excel
 
 import (
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	xlsx "github.com/tealeg/xlsx/v3"
 	"github.com/ungerik/go-fs"
 
 	"github.com/domonda/go-structtable"
 	"github.com/domonda/go-structtable/test"
+	"github.com/domonda/go-types/date"
+	"github.com/domonda/go-types/money"
 )
 
 func Test_RenderExcel(t *testing.T) {
@@ -50,3 +58,566 @@ func Test_sanitizeSheetName(t *testing.T) {
 		})
 	}
 }
+
+func Test_RenderExcelAutoFilter(t *testing.T) {
+	renderer, err := NewRenderer("Sheet 1")
+	assert.NoError(t, err, "Sheet 1")
+	renderer.EnableAutoFilter().SetTableName("Data")
+
+	err = structtable.Render(renderer, test.NewTable(3), true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+
+	_, err = renderer.Result()
+	assert.NoError(t, err, "Result")
+
+	assert.Equal(t, &xlsx.AutoFilter{TopLeftCell: "A1", BottomRightCell: "N4"}, renderer.currentSheet.AutoFilter)
+	if assert.Len(t, renderer.file.DefinedNames, 1) {
+		assert.Equal(t, "Data", renderer.file.DefinedNames[0].Name)
+		assert.Equal(t, "'Sheet 1'!$A$1:$N$4", renderer.file.DefinedNames[0].Data)
+	}
+
+	// Result can be called multiple times without defining names again
+	// but updates them to rows rendered in between
+	err = structtable.Render(renderer, test.NewTable(2), false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	_, err = renderer.Result()
+	assert.NoError(t, err, "Result")
+	if assert.Len(t, renderer.file.DefinedNames, 1) {
+		assert.Equal(t, "'Sheet 1'!$A$1:$N$6", renderer.file.DefinedNames[0].Data)
+	}
+}
+
+func Test_RenderExcelTableNamesInSheetOrder(t *testing.T) {
+	type row struct{ A string }
+
+	renderer, err := NewRenderer("Sheet 1")
+	require.NoError(t, err, "NewRenderer")
+	for i, name := range []string{"First", "Second", "Third", "Fourth"} {
+		if i > 0 {
+			err = renderer.AddSheet(name)
+			require.NoError(t, err, "AddSheet")
+		}
+		renderer.SetTableName(name)
+		err = structtable.Render(renderer, []row{{"a"}}, true, structtable.DefaultReflectColumnTitles)
+		require.NoError(t, err, "Render")
+	}
+	_, err = renderer.Result()
+	require.NoError(t, err, "Result")
+
+	var names []string
+	for _, definedName := range renderer.file.DefinedNames {
+		names = append(names, definedName.Name)
+	}
+	assert.Equal(t, []string{"First", "Second", "Third", "Fourth"}, names)
+}
+
+func Test_RenderExcelFooterOutsideTable(t *testing.T) {
+	type row struct {
+		Name   string
+		Amount float64
+	}
+
+	renderer, err := NewRenderer("S")
+	require.NoError(t, err, "NewRenderer")
+	renderer.EnableAutoFilter().SetTableName("data")
+
+	rows := []row{{"a", 1}, {"b", 2}}
+	err = structtable.RenderWithFooter(renderer, rows, true, structtable.DefaultReflectColumnTitles, func(any) []reflect.Value {
+		return []reflect.Value{reflect.ValueOf("Total"), reflect.ValueOf(3.0)}
+	})
+	require.NoError(t, err, "RenderWithFooter")
+
+	result, err := renderer.Result()
+	require.NoError(t, err, "Result")
+	file, err := xlsx.OpenBinary(result)
+	require.NoError(t, err, "OpenBinary")
+	assert.Equal(t, 4, file.Sheets[0].MaxRow, "footer row rendered")
+	if assert.NotNil(t, renderer.currentSheet.AutoFilter) {
+		assert.Equal(t, "B3", renderer.currentSheet.AutoFilter.BottomRightCell, "auto-filter without footer")
+	}
+	if assert.Len(t, renderer.file.DefinedNames, 1) {
+		assert.Equal(t, "'S'!$A$1:$B$3", renderer.file.DefinedNames[0].Data, "table name without footer")
+	}
+}
+
+func Test_RenderExcelStackedTables(t *testing.T) {
+	type row struct{ A, B string }
+
+	renderer, err := NewRenderer("Sheet 1")
+	assert.NoError(t, err, "NewRenderer")
+	assert.Equal(t, 0, renderer.CurrentRowCount())
+
+	renderer.RenderTitleText("First")
+	err = structtable.Render(renderer, []row{{"1", "2"}}, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	assert.Equal(t, 3, renderer.CurrentRowCount())
+
+	renderer.AddBlankRows(2)
+	renderer.RenderTitleText("Second")
+	err = structtable.Render(renderer, []row{{"3", "4"}}, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	assert.Equal(t, 8, renderer.CurrentRowCount())
+
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	file, err := xlsx.OpenBinary(result)
+	assert.NoError(t, err, "OpenBinary")
+	sheet := file.Sheets[0]
+	title, err := sheet.Cell(5, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, "Second", title.Value)
+	assert.Equal(t, 1, title.HMerge)
+	data, err := sheet.Cell(7, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, "4", data.Value)
+}
+
+func Test_RenderExcelFormula(t *testing.T) {
+	type row struct {
+		Price    float64
+		Quantity int
+		Total    Formula
+		Text     string
+	}
+	rows := []row{
+		{Price: 1.5, Quantity: 2, Total: "=A{row}*B{row}", Text: "=A1"},
+		{Price: 3, Quantity: 4, Total: "=A{row}*B{row}"},
+	}
+
+	renderer, err := NewRenderer("Sheet 1")
+	assert.NoError(t, err, "NewRenderer")
+	err = structtable.Render(renderer, rows, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+
+	for rowIndex, want := range []string{"A2*B2", "A3*B3"} {
+		cell, err := renderer.currentSheet.Cell(rowIndex+1, 2)
+		assert.NoError(t, err)
+		assert.Equal(t, want, cell.Formula())
+	}
+	text, err := renderer.currentSheet.Cell(1, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, "", text.Formula(), "strings are not formulas")
+	assert.Equal(t, "=A1", text.Value)
+}
+
+func Test_RenderExcelColumnDropdown(t *testing.T) {
+	type row struct {
+		Name   string
+		Status string
+	}
+	renderer, err := NewRenderer("Sheet 1")
+	require.NoError(t, err, "NewRenderer")
+	renderer.SetColumnDropdown(1, []string{"open", "paid", "cancelled"})
+
+	rows := []row{{"A", "open"}, {"B", "paid"}}
+	err = structtable.RenderWithFooter(renderer, rows, true, structtable.DefaultReflectColumnTitles, func(any) []reflect.Value {
+		return []reflect.Value{reflect.ValueOf("Sum")}
+	})
+	require.NoError(t, err, "Render")
+	_, err = renderer.Result()
+	require.NoError(t, err, "Result")
+	result, err := renderer.Result()
+	require.NoError(t, err, "Result")
+
+	// The validation survives a round-trip
+	file, err := xlsx.OpenBinary(result)
+	require.NoError(t, err, "OpenBinary")
+	validations := file.Sheets[0].DataValidations
+	if assert.Len(t, validations, 1, "Result applies the dropdown once") {
+		assert.Equal(t, "B2:B3", validations[0].Sqref, "data rows without header and footer")
+		assert.Equal(t, `"open,paid,cancelled"`, validations[0].Formula1)
+		assert.Equal(t, "list", validations[0].Type)
+	}
+
+	renderer.SetColumnDropdown(0, []string{strings.Repeat("x", 300)})
+	_, err = renderer.Result()
+	assert.Error(t, err, "too long options")
+
+	for _, option := range []string{"a,b", `say "hi"`} {
+		renderer.SetColumnDropdown(0, []string{"ok", option})
+		_, err = renderer.Result()
+		assert.Error(t, err, "option %q", option)
+	}
+	renderer.SetColumnDropdown(0, nil)
+
+	// Dropdowns are kept by Reset
+	renderer.Reset()
+	err = structtable.Render(renderer, rows, true, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render after Reset")
+	result, err = renderer.Result()
+	require.NoError(t, err, "Result after Reset")
+	file, err = xlsx.OpenBinary(result)
+	require.NoError(t, err, "OpenBinary")
+	if assert.Len(t, file.Sheets[0].DataValidations, 1, "dropdown after Reset") {
+		assert.Equal(t, "B2:B3", file.Sheets[0].DataValidations[0].Sqref)
+	}
+}
+
+func Test_RenderExcelGroupedHeader(t *testing.T) {
+	type row struct {
+		Name         string
+		Net, Tax     float64
+		Paid, Unpaid float64
+	}
+
+	renderer, err := NewRenderer("Sheet 1")
+	require.NoError(t, err, "NewRenderer")
+	renderer.EnableAutoFilter()
+
+	err = renderer.RenderGroupedHeader([]HeaderGroup{{"", 1}, {"Amount", 2}, {"Status", 2}})
+	require.NoError(t, err, "RenderGroupedHeader")
+	err = structtable.Render(renderer, []row{{"A", 1, 2, 3, 4}}, true, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+
+	result, err := renderer.Result()
+	require.NoError(t, err, "Result")
+	file, err := xlsx.OpenBinary(result)
+	require.NoError(t, err, "OpenBinary")
+	sheet := file.Sheets[0]
+	for col, title := range map[int]string{1: "Amount", 3: "Status"} {
+		cell, err := sheet.Cell(0, col)
+		require.NoError(t, err)
+		assert.Equal(t, title, cell.Value)
+		assert.Equal(t, 1, cell.HMerge, "merged over two columns")
+		assert.True(t, cell.GetStyle().Font.Bold, "bold")
+		assert.Equal(t, "center", cell.GetStyle().Alignment.Horizontal)
+	}
+	header, err := sheet.Cell(1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, "Tax", header.Value)
+	assert.Equal(t, &xlsx.AutoFilter{TopLeftCell: "A2", BottomRightCell: "E3"}, renderer.currentSheet.AutoFilter, "grouped header not filtered")
+
+	err = renderer.RenderGroupedHeader([]HeaderGroup{{"Invalid", 0}})
+	assert.Error(t, err, "span less than one")
+}
+
+func Test_RenderExcelSheetConfig(t *testing.T) {
+	type row struct {
+		Date date.Date
+		Note *string
+	}
+	rows := []row{{Date: "2024-03-01"}}
+
+	renderer, err := NewRenderer("Default")
+	require.NoError(t, err, "NewRenderer")
+	renderer.Config.Null = "-"
+	err = structtable.Render(renderer, rows, false, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+
+	usConfig := renderer.Config
+	usConfig.Date = "mm/dd/yyyy"
+	usConfig.Null = "n/a"
+	err = renderer.AddSheet("US", usConfig)
+	require.NoError(t, err, "AddSheet")
+	err = structtable.Render(renderer, rows, false, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+
+	otherConfig := usConfig
+	otherConfig.Null = "other"
+	err = RenderWithConfig(renderer, rows, false, structtable.DefaultReflectColumnTitles, otherConfig)
+	require.NoError(t, err, "RenderWithConfig")
+
+	sheets := renderer.file.Sheets
+	cell := func(sheet, row, col int) *xlsx.Cell {
+		c, err := sheets[sheet].Cell(row, col)
+		require.NoError(t, err)
+		return c
+	}
+	assert.Equal(t, "dd.mm.yyyy", cell(0, 0, 0).NumFmt)
+	assert.Equal(t, "-", cell(0, 0, 1).Value)
+	assert.Equal(t, "mm/dd/yyyy", cell(1, 0, 0).NumFmt)
+	assert.Equal(t, "n/a", cell(1, 0, 1).Value)
+	assert.Equal(t, "other", cell(1, 1, 1).Value, "RenderWithConfig overrides sheet config")
+	assert.Equal(t, "n/a", renderer.config().Null, "sheet config restored")
+	assert.Equal(t, "-", renderer.Config.Null, "renderer Config unchanged")
+
+	renderer.Reset()
+	assert.Equal(t, "n/a", renderer.config().Null, "sheet config kept by Reset")
+}
+
+func Test_RenderExcelComplexValues(t *testing.T) {
+	type row struct {
+		Map   map[string]int
+		Slice []string
+		Chan  chan int
+	}
+	renderer, err := NewRenderer("Sheet 1")
+	require.NoError(t, err, "NewRenderer")
+	rows := []row{{map[string]int{"a": 1}, []string{"x", "y"}, make(chan int)}}
+	err = structtable.Render(renderer, rows, false, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+	renderer.Config.SliceJoiner = ""
+	err = structtable.Render(renderer, rows, false, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+
+	sheet := renderer.file.Sheets[0]
+	for row, wants := range [][]string{{`{"a":1}`, "x, y", ""}, {`{"a":1}`, `["x","y"]`, ""}} {
+		for col, want := range wants {
+			cell, err := sheet.Cell(row, col)
+			require.NoError(t, err)
+			assert.Equal(t, want, cell.Value)
+		}
+	}
+}
+
+func Test_RenderExcelEnumFormatters(t *testing.T) {
+	type status uint8
+	type row struct {
+		Status status
+	}
+	renderer, err := NewRenderer("Sheet 1")
+	require.NoError(t, err, "NewRenderer")
+	renderer.Config.EnumFormatters = structtable.EnumFormatters{
+		reflect.TypeOf(status(0)): {1: "open"},
+	}
+	err = structtable.Render(renderer, []row{{1}, {2}}, false, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+
+	sheet := renderer.file.Sheets[0]
+	cell, err := sheet.Cell(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "open", cell.Value)
+	cell, err = sheet.Cell(1, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "2", cell.Value, "unmapped value as number")
+	assert.Equal(t, xlsx.CellTypeNumeric, cell.Type())
+}
+
+func Test_RenderExcelNonFiniteFloats(t *testing.T) {
+	type row struct {
+		Float  float64
+		Amount money.Amount
+	}
+	renderer, err := NewRenderer("Sheet 1")
+	require.NoError(t, err, "NewRenderer")
+	renderer.Config.NaNString = "N/A"
+	err = structtable.Render(renderer, []row{{math.NaN(), money.Amount(math.Inf(1))}}, false, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	require.NoError(t, err, "Result")
+
+	file, err := xlsx.OpenBinary(result)
+	require.NoError(t, err, "OpenBinary")
+	nan, err := file.Sheets[0].Cell(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "N/A", nan.Value)
+	assert.Equal(t, xlsx.CellTypeString, nan.Type())
+	inf, err := file.Sheets[0].Cell(0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "", inf.Value)
+}
+
+func Test_RenderExcelTypeRegistry(t *testing.T) {
+	type sku string
+	type row struct {
+		SKU sku
+	}
+	registry := structtable.NewTypeRegistry().Register(reflect.TypeOf(sku("")), structtable.TypeRegistration{
+		ExcelCell: ExcelCellWriterFunc(func(cell *xlsx.Cell, val reflect.Value, config *ExcelFormatConfig) error {
+			cell.SetString("SKU-" + val.String())
+			return nil
+		}),
+	})
+	renderer, err := NewRenderer("Sheet 1")
+	require.NoError(t, err, "NewRenderer")
+	require.NoError(t, renderer.SetTypeRegistry(registry), "SetTypeRegistry")
+	err = structtable.Render(renderer, []row{{"a1"}}, false, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+
+	cell, err := renderer.file.Sheets[0].Cell(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "SKU-a1", cell.Value)
+
+	registry.Register(reflect.TypeOf(sku("")), structtable.TypeRegistration{ExcelCell: "invalid"})
+	assert.Error(t, renderer.SetTypeRegistry(registry), "ExcelCell not implementing ExcelCellWriter")
+}
+
+func Test_RenderExcelDates(t *testing.T) {
+	type row struct {
+		Date date.Date
+		Time time.Time
+	}
+	rows := []row{{"2024-01-31", time.Date(2024, 1, 31, 12, 30, 0, 0, time.UTC)}}
+
+	for _, date1904 := range []bool{true, false} {
+		renderer, err := NewRenderer("Sheet 1")
+		require.NoError(t, err, "NewRenderer")
+		assert.False(t, renderer.Date1904(), "1900 date system by default")
+		renderer.SetDate1904(date1904)
+		err = structtable.Render(renderer, rows, false, structtable.DefaultReflectColumnTitles)
+		require.NoError(t, err, "Render")
+		result, err := renderer.Result()
+		require.NoError(t, err, "Result")
+
+		file, err := xlsx.OpenBinary(result)
+		require.NoError(t, err, "OpenBinary")
+		assert.Equal(t, date1904, file.Date1904)
+		cell, err := file.Sheets[0].Cell(0, 0)
+		require.NoError(t, err)
+		d, err := cell.GetTime(file.Date1904)
+		require.NoError(t, err)
+		assert.Equal(t, time.Date(2024, 1, 31, 0, 0, 0, 0, time.UTC), d, "date with Date1904 %t", date1904)
+		cell, err = file.Sheets[0].Cell(0, 1)
+		require.NoError(t, err)
+		tm, err := cell.GetTime(file.Date1904)
+		require.NoError(t, err)
+		assert.WithinDuration(t, rows[0].Time, tm, time.Millisecond, "time with Date1904 %t", date1904)
+	}
+
+	renderer, err := NewRenderer("Sheet 1")
+	require.NoError(t, err, "NewRenderer")
+	renderer.Config.DatesAsText = true
+	err = structtable.Render(renderer, rows, false, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+	cell, err := renderer.file.Sheets[0].Cell(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-01-31", cell.Value)
+	assert.Equal(t, xlsx.CellTypeString, cell.Type())
+	cell, err = renderer.file.Sheets[0].Cell(0, 1)
+	require.NoError(t, err)
+	assert.Equal(t, "2024-01-31T12:30:00Z", cell.Value)
+}
+
+func Test_RenderExcelSheetNameCollision(t *testing.T) {
+	renderer, err := NewRenderer("Quarterly Revenue Report for Region North")
+	require.NoError(t, err)
+	err = renderer.AddSheet("Quarterly Revenue Report for Region South")
+	require.NoError(t, err)
+	err = renderer.AddSheet("quarterly revenue report for region west")
+	require.NoError(t, err)
+	err = renderer.AddSheet("Data")
+	require.NoError(t, err)
+	err = renderer.AddSheet("Data")
+	require.NoError(t, err)
+	assert.Equal(t, "Data (2)", renderer.CurrentSheetName())
+
+	var names []string
+	for _, sheet := range renderer.file.Sheets {
+		names = append(names, sheet.Name)
+	}
+	assert.Equal(t, []string{
+		"Quarterly Revenue Report for R…",
+		"Quarterly Revenue Report fo (2)",
+		"quarterly revenue report fo (3)",
+		"Data",
+		"Data (2)",
+	}, names)
+
+	renderer.SheetNameFunc = func(name string, existing []string) string {
+		return fmt.Sprintf("%d %s", len(existing)+1, name)
+	}
+	err = renderer.AddSheet("Data")
+	require.NoError(t, err)
+	assert.Equal(t, "6 Data", renderer.CurrentSheetName())
+}
+
+func Test_RenderExcelBoolText(t *testing.T) {
+	type row struct {
+		Active   bool
+		Optional *bool
+	}
+	yes := true
+	renderer, err := NewRenderer("Sheet 1")
+	require.NoError(t, err, "NewRenderer")
+	err = structtable.Render(renderer, []row{{true, nil}}, false, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+	cell, err := renderer.file.Sheets[0].Cell(0, 0)
+	require.NoError(t, err)
+	assert.Equal(t, xlsx.CellTypeBool, cell.Type(), "native bool by default")
+
+	renderer.Config.BoolTrue = "Yes"
+	renderer.Config.BoolFalse = "No"
+	err = structtable.Render(renderer, []row{{true, &yes}, {false, nil}}, false, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+	for r, expected := range []string{"Yes", "Yes", "No", ""} {
+		cell, err := renderer.file.Sheets[0].Cell(1+r/2, r%2)
+		require.NoError(t, err)
+		assert.Equal(t, expected, cell.Value)
+		if expected != "" {
+			assert.Equal(t, xlsx.CellTypeString, cell.Type())
+			assert.NotEqual(t, "right", cell.GetStyle().Alignment.Horizontal, "no number alignment")
+		}
+	}
+}
+
+func Test_RenderExcelSliceJoiner(t *testing.T) {
+	type row struct {
+		Tags    []string
+		Numbers []int
+		Nested  [][]string
+	}
+	renderer, err := NewRenderer("Sheet 1")
+	require.NoError(t, err, "NewRenderer")
+	renderer.Config.SliceJoiner = structtable.DefaultSliceJoiner
+	err = structtable.Render(renderer, []row{{[]string{"a", "b"}, []int{1, 2, 3}, [][]string{{"x"}}}}, false, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+
+	sheet := renderer.file.Sheets[0]
+	for col, want := range []string{"a, b", "1, 2, 3", `[["x"]]`} {
+		cell, err := sheet.Cell(0, col)
+		require.NoError(t, err)
+		assert.Equal(t, want, cell.Value)
+		assert.Equal(t, xlsx.CellTypeString, cell.Type())
+	}
+}
+
+func Test_RenderExcelWrapHeaders(t *testing.T) {
+	type row struct {
+		Short string
+		Long  string `col:"A very long column title that needs wrapping"`
+	}
+	renderer, err := NewRenderer("Sheet 1")
+	require.NoError(t, err, "NewRenderer")
+	err = structtable.Render(renderer, []row{{"a", "b"}}, true, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+	sheet := renderer.file.Sheets[0]
+	cell, err := sheet.Cell(0, 1)
+	require.NoError(t, err)
+	assert.False(t, cell.GetStyle().Alignment.WrapText, "not wrapped by default")
+
+	renderer.WrapHeaders = true
+	err = structtable.Render(renderer, []row{{"a", "b"}}, true, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+	for col := range 2 {
+		cell, err := sheet.Cell(2, col)
+		require.NoError(t, err)
+		assert.True(t, cell.GetStyle().Alignment.WrapText, "header cell wrapped")
+		assert.True(t, cell.GetStyle().Font.Bold, "header font kept")
+	}
+	headerRow, err := sheet.Row(2)
+	require.NoError(t, err)
+	assert.Equal(t, 5*headerLineHeight, headerRow.GetHeight(), "44 characters in 9 character wide lines")
+	dataCell, err := sheet.Cell(3, 1)
+	require.NoError(t, err)
+	assert.False(t, dataCell.GetStyle().Alignment.WrapText, "data cell not wrapped")
+}
+
+func Test_RenderExcelBlankZero(t *testing.T) {
+	type status int
+	type row struct {
+		Int      int
+		Float    float64
+		Amount   money.Amount
+		Duration time.Duration
+		Status   status
+		Bool     bool
+	}
+	renderer, err := NewRenderer("Sheet 1")
+	require.NoError(t, err, "NewRenderer")
+	renderer.Config.BlankZero = true
+	renderer.Config.EnumFormatters = structtable.EnumFormatters{reflect.TypeOf(status(0)): {0: "Draft"}}
+	err = structtable.Render(renderer, []row{{}, {Int: -1, Float: -0.5, Amount: -2}}, false, structtable.DefaultReflectColumnTitles)
+	require.NoError(t, err, "Render")
+
+	sheet := renderer.file.Sheets[0]
+	for col, want := range []string{"", "", "", "0", "Draft", "0"} {
+		cell, err := sheet.Cell(0, col)
+		require.NoError(t, err)
+		assert.Equal(t, want, cell.Value, "column %d of zero row", col)
+	}
+	for col, want := range []string{"-1", "-0.5", "-2"} {
+		cell, err := sheet.Cell(1, col)
+		require.NoError(t, err)
+		assert.Equal(t, want, cell.Value, "negative values not blanked")
+	}
+}
@@ -0,0 +1,57 @@
+package structtable
+
+import (
+	"reflect"
+
+	"github.com/domonda/go-errs"
+)
+
+// LongRowPolicy defines how RenderFixedColumnCount
+// handles rows with more columns than the fixed count.
+type LongRowPolicy int
+
+const (
+	// TruncateLongRows renders only the first columns of long rows
+	TruncateLongRows LongRowPolicy = iota
+	// ErrorOnLongRows returns an error for the first long row
+	ErrorOnLongRows
+)
+
+// RenderFixedColumnCount renders like Render but guarantees
+// that the header row and every row have numCols columns,
+// for column mappers that reflect a varying number of columns.
+// Short rows are padded with invalid reflect.Value elements
+// that renderers render as null values, and short header rows
+// with empty titles.
+// Rows with more than numCols columns are handled as defined
+// by longRows, a long header row is always truncated.
+func RenderFixedColumnCount(renderer Renderer, structSlice any, renderTitleRow bool, columnMapper ColumnMapper, numCols int, longRows LongRowPolicy) error {
+	if numCols < 0 {
+		return errs.New("numCols can't be negative")
+	}
+	rows, err := ReflectRows(structSlice)
+	if err != nil {
+		return err
+	}
+
+	columnTitles, rowReflector := columnMapper.ColumnTitlesAndRowReflector(rows.Type().Elem())
+
+	titles := make([]string, numCols)
+	copy(titles, columnTitles)
+
+	fixRow := func(i int, columnValues []reflect.Value) ([]reflect.Value, error) {
+		switch {
+		case len(columnValues) < numCols:
+			padded := make([]reflect.Value, numCols)
+			copy(padded, columnValues)
+			return padded, nil
+		case len(columnValues) > numCols:
+			if longRows == ErrorOnLongRows {
+				return nil, errs.Errorf("row %d has %d columns, more than the fixed column count %d", i, len(columnValues), numCols)
+			}
+			return columnValues[:numCols], nil
+		}
+		return columnValues, nil
+	}
+	return renderRows(renderer, titles, renderTitleRow, reflectRows(rows, 0, rows.Len(), rowReflector), fixRow)
+}
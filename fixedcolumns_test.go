@@ -0,0 +1,39 @@
+package structtable
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderFixedColumnCount(t *testing.T) {
+	rows := []renderTestRow{{A: "a1", B: "b1"}, {A: "a2"}}
+	// Reflects column B only if it is not empty
+	ragged := ColumnMapperFunc(func(structType reflect.Type) ([]string, RowReflector) {
+		return []string{"A", "B"}, RowReflectorFunc(func(structValue reflect.Value) []reflect.Value {
+			values := StructFieldValues(structValue)
+			if values[1].String() == "" {
+				return values[:1]
+			}
+			return values
+		})
+	})
+
+	r := new(recordingRenderer)
+	err := RenderFixedColumnCount(r, rows, true, ragged, 3, ErrorOnLongRows)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A", "B", ""}, r.header)
+	assert.Equal(t, [][]string{{"a1", "b1", "<invalid Value>"}, {"a2", "<invalid Value>", "<invalid Value>"}}, r.rows)
+
+	r = new(recordingRenderer)
+	err = RenderFixedColumnCount(r, rows, true, ragged, 1, TruncateLongRows)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A"}, r.header)
+	assert.Equal(t, [][]string{{"a1"}, {"a2"}}, r.rows)
+
+	r = new(recordingRenderer)
+	err = RenderFixedColumnCount(r, rows, false, ragged, 1, ErrorOnLongRows)
+	assert.ErrorContains(t, err, "row 0 has 2 columns")
+	assert.Empty(t, r.rows)
+}
@@ -0,0 +1,167 @@
+package fixedwidth
+
+import (
+	"bytes"
+	"io"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/domonda/go-errs"
+	"github.com/domonda/go-types/strfmt"
+	"github.com/ungerik/go-fs"
+
+	"github.com/domonda/go-structtable"
+)
+
+// FieldSpec defines the position of a field within a line
+// and the name of the struct field it is read into.
+type FieldSpec struct {
+	// Start is the offset of the first byte or rune of the field
+	Start int `json:"start"`
+	// Width is the number of bytes or runes of the field
+	Width       int    `json:"width"`
+	StructField string `json:"structField"`
+}
+
+// Reader implements structtable.Reader for fixed-width text
+// where every field of a line is at a fixed position.
+//
+// Lines shorter than the end of a field are read
+// as if they were padded with spaces, so the missing
+// part of the field is read as empty string.
+// Leading and trailing spaces are trimmed from all fields.
+type Reader struct {
+	Fields     []FieldSpec        `json:"fields"`
+	ScanConfig *strfmt.ScanConfig `json:"config"`
+	// Runes defines if the positions of Fields
+	// are counted in runes instead of bytes
+	Runes bool `json:"runes,omitempty"`
+
+	lines []string
+}
+
+// NewReader reads the lines from an io.Reader
+func NewReader(reader io.Reader, fields []FieldSpec, runes bool, scanConfig ...*strfmt.ScanConfig) (r *Reader, err error) {
+	defer errs.WrapWithFuncParams(&err, reader, fields, runes, scanConfig)
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return NewReaderFromLines(SplitLines(data), fields, runes, scanConfig...)
+}
+
+// NewReaderFromLines returns a Reader that uses already split lines
+func NewReaderFromLines(lines []string, fields []FieldSpec, runes bool, scanConfig ...*strfmt.ScanConfig) (r *Reader, err error) {
+	defer errs.WrapWithFuncParams(&err, lines, fields, runes, scanConfig)
+
+	for _, field := range fields {
+		if field.Start < 0 || field.Width < 0 {
+			return nil, errs.Errorf("invalid field %q with start %d and width %d", field.StructField, field.Start, field.Width)
+		}
+	}
+	r = &Reader{
+		Fields:     fields,
+		ScanConfig: strfmt.DefaultScanConfig,
+		Runes:      runes,
+		lines:      lines,
+	}
+	if len(scanConfig) > 0 && scanConfig[0] != nil {
+		r.ScanConfig = scanConfig[0]
+	}
+	return r, nil
+}
+
+// NewReaderFromFile reads from a fs.FileReader
+func NewReaderFromFile(file fs.FileReader, fields []FieldSpec, runes bool, scanConfig ...*strfmt.ScanConfig) (r *Reader, err error) {
+	defer errs.WrapWithFuncParams(&err, file, fields, runes, scanConfig)
+
+	reader, err := file.OpenReader()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return NewReader(reader, fields, runes, scanConfig...)
+}
+
+// SplitLines splits data into lines separated by "\n" or "\r\n".
+// A final empty line after the last newline is not returned.
+func SplitLines(data []byte) []string {
+	data = bytes.TrimSuffix(data, []byte("\n"))
+	if len(data) == 0 {
+		return nil
+	}
+	lines := strings.Split(string(data), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSuffix(line, "\r")
+	}
+	return lines
+}
+
+func (r *Reader) NumRows() int {
+	return len(r.lines)
+}
+
+// ReadRowStrings returns the trimmed fields of the line
+// with the passed index in the order of Fields.
+//
+// If Runes is false, then a field boundary can cut through
+// a multi-byte UTF-8 character. An error is returned
+// for such a field of a valid UTF-8 line instead of
+// returning invalid UTF-8, use Runes for UTF-8 text
+// with multi-byte characters.
+func (r *Reader) ReadRowStrings(index int) ([]string, error) {
+	if index < 0 || index >= len(r.lines) {
+		return nil, errs.Errorf("row index %d out of bounds [0..%d)", index, len(r.lines))
+	}
+	row := make([]string, len(r.Fields))
+	if r.Runes {
+		line := []rune(r.lines[index])
+		for i, field := range r.Fields {
+			start, end := fieldBounds(field, len(line))
+			row[i] = strings.TrimSpace(string(line[start:end]))
+		}
+	} else {
+		line := r.lines[index]
+		validLine := utf8.ValidString(line)
+		for i, field := range r.Fields {
+			start, end := fieldBounds(field, len(line))
+			row[i] = strings.TrimSpace(line[start:end])
+			if validLine && !utf8.ValidString(row[i]) {
+				return nil, errs.Errorf("field %q of row %d cuts through a multi-byte UTF-8 character at byte positions %d to %d", field.StructField, index, start, end)
+			}
+		}
+	}
+	return row, nil
+}
+
+func (r *Reader) ReadAllStrings() ([][]string, error) {
+	return structtable.ReadAllRowStrings(r)
+}
+
+func (r *Reader) ReadRow(index int, destStruct reflect.Value) error {
+	row, err := r.ReadRowStrings(index)
+	if err != nil {
+		return err
+	}
+	for i, field := range r.Fields {
+		destStructField := destStruct.FieldByName(field.StructField)
+		if !destStructField.IsValid() {
+			continue
+		}
+		err := strfmt.Scan(destStructField, row[i], r.ScanConfig)
+		if err != nil {
+			return errs.Errorf("error parsing row %d, field %q string %q: %w", index, field.StructField, row[i], err)
+		}
+	}
+	return nil
+}
+
+// fieldBounds returns the start and end offsets of field
+// limited to lineLen, so that the part of a field
+// beyond the end of a line is empty.
+func fieldBounds(field FieldSpec, lineLen int) (start, end int) {
+	return min(field.Start, lineLen), min(field.Start+field.Width, lineLen)
+}
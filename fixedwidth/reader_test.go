@@ -0,0 +1,75 @@
+package fixedwidth
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-structtable"
+	"github.com/domonda/go-types/date"
+)
+
+func TestReader(t *testing.T) {
+	type booking struct {
+		Date   date.Date
+		Name   string
+		Amount *float64
+	}
+	const data = "DATE      NAME      AMOUNT\r\n" +
+		"2024-01-05Müller     12.50\r\n" +
+		"2024-02-01Smith          3\r\n" +
+		"2024-03-10Short\r\n"
+	fields := []FieldSpec{
+		{Start: 0, Width: 10, StructField: "Date"},
+		{Start: 10, Width: 10, StructField: "Name"},
+		{Start: 20, Width: 6, StructField: "Amount"},
+	}
+
+	reader, err := NewReader(strings.NewReader(data), fields, true)
+	require.NoError(t, err, "NewReader")
+	assert.Equal(t, 4, reader.NumRows())
+
+	row, err := reader.ReadRowStrings(1)
+	assert.NoError(t, err, "ReadRowStrings")
+	assert.Equal(t, []string{"2024-01-05", "Müller", "12.50"}, row)
+	row, err = reader.ReadRowStrings(3)
+	assert.NoError(t, err, "ReadRowStrings")
+	assert.Equal(t, []string{"2024-03-10", "Short", ""}, row, "short line")
+
+	var bookings []booking
+	header, err := structtable.Read(reader, &bookings, 1)
+	require.NoError(t, err, "Read")
+	assert.Equal(t, [][]string{{"DATE", "NAME", "AMOUNT"}}, header)
+	amount1, amount2 := 12.5, 3.0
+	assert.Equal(t, []booking{
+		{"2024-01-05", "Müller", &amount1},
+		{"2024-02-01", "Smith", &amount2},
+		{"2024-03-10", "Short", nil},
+	}, bookings)
+
+	byteReader, err := NewReader(strings.NewReader(data), fields, false)
+	require.NoError(t, err, "NewReader")
+	row, err = byteReader.ReadRowStrings(1)
+	assert.NoError(t, err, "ReadRowStrings")
+	assert.Equal(t, "Müller", row[1], "umlaut takes two bytes")
+	assert.Equal(t, "12.5", row[2], "shifted by one byte")
+
+	cutFields := []FieldSpec{
+		{Start: 0, Width: 11, StructField: "Date"},
+		{Start: 11, Width: 9, StructField: "Name"},
+	}
+	cutReader, err := NewReader(strings.NewReader("2024-01-05Ä"), cutFields, false)
+	require.NoError(t, err, "NewReader")
+	_, err = cutReader.ReadRowStrings(0)
+	assert.Error(t, err, "field cuts through multi-byte character")
+	_, err = cutReader.ReadAllStrings()
+	assert.Error(t, err, "ReadAllStrings returns the error")
+
+	latin1Reader, err := NewReader(strings.NewReader("2024-01-05\xc4"), cutFields, false)
+	require.NoError(t, err, "NewReader")
+	row, err = latin1Reader.ReadRowStrings(0)
+	assert.NoError(t, err, "lines that are not UTF-8 are read as is")
+	assert.Equal(t, []string{"2024-01-05\xc4", ""}, row)
+}
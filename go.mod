@@ -1,6 +1,6 @@
 module github.com/domonda/go-structtable
 
-go 1.22
+go 1.23
 
 require (
 	github.com/domonda/go-errs v0.0.0-20230920094343-6b122da4d22f
@@ -8,6 +8,7 @@ require (
 	github.com/stretchr/testify v1.9.0
 	github.com/tealeg/xlsx/v3 v3.3.5
 	github.com/ungerik/go-fs v0.0.0-20240118121925-91844f9bdba8
+	golang.org/x/text v0.14.0
 )
 
 require (
@@ -27,7 +28,6 @@ require (
 	github.com/shabbyrobe/xmlwriter v0.0.0-20230525083848-85336ec334fa // indirect
 	github.com/ungerik/go-reflection v0.0.0-20240110134735-61cada706fec // indirect
 	golang.org/x/sys v0.17.0 // indirect
-	golang.org/x/text v0.14.0 // indirect
 	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
 
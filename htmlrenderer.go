@@ -10,6 +10,7 @@ import (
 
 	"github.com/ungerik/go-fs"
 
+	"github.com/domonda/go-errs"
 	"github.com/domonda/go-types/strfmt"
 )
 
@@ -30,36 +31,124 @@ type HTMLTableConfig struct {
 	HeaderCellClass string
 	DataRowClass    string
 	DataCellClass   string
+	FooterRowClass  string
+	FooterCellClass string
+	// TextRowClass is the class of rows rendered by RenderTextRow
+	TextRowClass string
+	// ColumnWidths are optional CSS lengths like "10em" or "15%"
+	// rendered as colgroup before the header row.
+	// Columns without a width at their index get no explicit width.
+	ColumnWidths []string
+	// Dir is the text direction "ltr" or "rtl" of the table
+	// rendered as dir attribute of the table element.
+	// An empty string renders no dir attribute.
+	Dir string
+	// ColumnDirs are optional text directions "ltr" or "rtl"
+	// rendered as dir attribute of the cells of a column
+	// that differs from the direction of the table,
+	// like numbers or IBANs within a right-to-left table.
+	// Columns without a direction at their index get no dir attribute.
+	ColumnDirs []string
+	// WrapHeaders renders header cells with the style
+	// white-space:normal so that long titles wrap
+	// instead of making the table too wide.
+	WrapHeaders bool
+	// CellWrapTag is the name of an element like "span"
+	// that wraps the content of every data and footer cell
+	// for CSS targeting or for scripts reading the cell text.
+	// An empty string renders the content without wrapping.
+	CellWrapTag string
 }
 
 // HTMLRenderer implements Renderer by using a HTMLFormatRenderer
 // for a specific text based table format.
+// HTMLRenderer is not safe for concurrent use,
+// call Reset to reuse it for another table.
 type HTMLRenderer struct {
-	format      HTMLFormatRenderer
-	TableConfig *HTMLTableConfig
-	txtConfig   *strfmt.FormatConfig
-	buf         bytes.Buffer
+	format       HTMLFormatRenderer
+	TableConfig  *HTMLTableConfig
+	txtConfig    *strfmt.FormatConfig
+	buf          bytes.Buffer
+	tableWritten bool
+	// section is the currently open table section element
+	// "thead", "tbody", "tfoot", or "" if none is open
+	section string
+	// numCols is the maximum number of columns rendered so far
+	numCols  int
+	finished bool
+	// RawHTMLTypes are trusted types whose formatted values
+	// are written as HTML without escaping.
+	// The values of all other types are escaped,
+	// even if they have a TypeFormatter, because formatters
+	// may include user data which would allow cross-site scripting.
+	RawHTMLTypes map[reflect.Type]bool
+	// SliceJoiner joins the formatted elements of slices and arrays
+	// without TypeFormatter if not empty, see FormatJoinedSlice.
+	// NewHTMLRenderer sets it to DefaultSliceJoiner.
+	SliceJoiner string
 }
 
 func NewHTMLRenderer(format HTMLFormatRenderer, TableConfig *HTMLTableConfig, config *strfmt.FormatConfig) *HTMLRenderer {
-	return &HTMLRenderer{format: format, TableConfig: TableConfig, txtConfig: config}
+	return &HTMLRenderer{format: format, TableConfig: TableConfig, txtConfig: config, SliceJoiner: DefaultSliceJoiner}
 }
 
-func (htm *HTMLRenderer) RenderHeaderRow(columnTitles []string) error {
+// FormatConfig returns the config used to format values.
+// Implements FormatConfigurable.
+func (htm *HTMLRenderer) FormatConfig() *strfmt.FormatConfig {
+	return htm.txtConfig
+}
+
+// SetFormatConfig sets the config used to format values.
+// Implements FormatConfigurable.
+func (htm *HTMLRenderer) SetFormatConfig(config *strfmt.FormatConfig) {
+	htm.txtConfig = config
+}
+
+// SetTypeRegistry sets a copy of the current FormatConfig
+// with the Text formatters of registry as FormatConfig
+// and the types registered with RawHTML as RawHTMLTypes.
+func (htm *HTMLRenderer) SetTypeRegistry(registry *TypeRegistry) {
+	htm.txtConfig = registry.FormatConfig(htm.txtConfig)
+	htm.RawHTMLTypes = registry.RawHTMLTypes()
+}
+
+// Reset clears the rendered HTML so that
+// the HTMLRenderer can be reused for another table
+// with the same TableConfig.
+func (htm *HTMLRenderer) Reset() {
+	htm.buf.Reset()
+	htm.tableWritten = false
+	htm.section = ""
+	htm.numCols = 0
+	htm.finished = false
+}
+
+// writeTableBeginIfMissing writes everything before the table,
+// the table element and its caption if not already written.
+func (htm *HTMLRenderer) writeTableBeginIfMissing() error {
+	if htm.finished {
+		return ErrFinished
+	}
+	if htm.tableWritten {
+		return nil
+	}
 	err := htm.format.RenderBeforeTable(&htm.buf)
 	if err != nil {
 		return err
 	}
 
+	var attrs string
 	if htm.TableConfig.TableClass != "" {
-		err = htm.write("<table class='%s'><tbody>\n", html.EscapeString(htm.TableConfig.TableClass))
-	} else {
-		err = htm.write("<table><tbody>\n")
+		attrs = fmt.Sprintf(" class='%s'", html.EscapeString(htm.TableConfig.TableClass))
+	}
+	if htm.TableConfig.Dir != "" {
+		attrs += fmt.Sprintf(" dir='%s'", html.EscapeString(htm.TableConfig.Dir))
 	}
+	err = htm.write("<table%s>\n", attrs)
 	if err != nil {
 		return err
 	}
-	caption := htm.TableConfig.Caption
+	caption := html.EscapeString(htm.TableConfig.Caption)
 	if caption != "" {
 		if htm.TableConfig.CaptionClass != "" {
 			err = htm.write("<caption class='%s'>%s</caption>\n", html.EscapeString(htm.TableConfig.CaptionClass), caption)
@@ -70,20 +159,95 @@ func (htm *HTMLRenderer) RenderHeaderRow(columnTitles []string) error {
 			return err
 		}
 	}
+	htm.tableWritten = true
+	return nil
+}
+
+// openSection closes the currently open table section element
+// and opens the passed one if it is not already open.
+func (htm *HTMLRenderer) openSection(section string) error {
+	if htm.section == section {
+		return nil
+	}
+	err := htm.closeSection()
+	if err != nil {
+		return err
+	}
+	err = htm.write("<%s>\n", section)
+	if err != nil {
+		return err
+	}
+	htm.section = section
+	return nil
+}
+
+// closeSection closes the currently open table section element if any.
+func (htm *HTMLRenderer) closeSection() error {
+	if htm.section == "" {
+		return nil
+	}
+	err := htm.write("</%s>\n", htm.section)
+	if err != nil {
+		return err
+	}
+	htm.section = ""
+	return nil
+}
+
+// writeColGroup writes a colgroup with numCols col elements
+// if TableConfig.ColumnWidths is set.
+func (htm *HTMLRenderer) writeColGroup(numCols int) error {
+	if len(htm.TableConfig.ColumnWidths) == 0 {
+		return nil
+	}
+	err := htm.write("<colgroup>")
+	if err != nil {
+		return err
+	}
+	for i := 0; i < numCols; i++ {
+		if i < len(htm.TableConfig.ColumnWidths) && htm.TableConfig.ColumnWidths[i] != "" {
+			err = htm.write("<col style='width:%s'>", html.EscapeString(htm.TableConfig.ColumnWidths[i]))
+		} else {
+			err = htm.write("<col>")
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return htm.write("</colgroup>\n")
+}
+
+func (htm *HTMLRenderer) RenderHeaderRow(columnTitles []string) error {
+	err := htm.writeTableBeginIfMissing()
+	if err != nil {
+		return err
+	}
+	if htm.section == "" {
+		err = htm.writeColGroup(len(columnTitles))
+		if err != nil {
+			return err
+		}
+	}
+	err = htm.openSection("thead")
+	if err != nil {
+		return err
+	}
+	htm.numCols = max(htm.numCols, len(columnTitles))
+
 	if htm.TableConfig.HeaderRowClass != "" || htm.TableConfig.RowClass != "" {
-		err = htm.write("<tr class='%s'>\n", strings.TrimSpace(htm.TableConfig.HeaderRowClass+" "+htm.TableConfig.RowClass))
+		err = htm.write("<tr class='%s'>\n", html.EscapeString(strings.TrimSpace(htm.TableConfig.HeaderRowClass+" "+htm.TableConfig.RowClass)))
 	} else {
 		err = htm.write("<tr>\n")
 	}
 	if err != nil {
 		return err
 	}
-	for _, columnTitle := range columnTitles {
-		if htm.TableConfig.HeaderCellClass != "" || htm.TableConfig.CellClass != "" {
-			err = htm.write("<th class='%s'>%s</th>", strings.TrimSpace(htm.TableConfig.HeaderCellClass+" "+htm.TableConfig.CellClass), columnTitle)
-		} else {
-			err = htm.write("<th>%s</th>", columnTitle)
+	for col, columnTitle := range columnTitles {
+		attrs := htm.cellAttrs(htm.TableConfig.HeaderCellClass, col)
+		if htm.TableConfig.WrapHeaders {
+			attrs += " style='white-space:normal'"
 		}
+		err = htm.write("<th%s>%s</th>", attrs, html.EscapeString(columnTitle))
 		if err != nil {
 			return err
 		}
@@ -93,9 +257,22 @@ func (htm *HTMLRenderer) RenderHeaderRow(columnTitles []string) error {
 }
 
 func (htm *HTMLRenderer) RenderRow(columnValues []reflect.Value) error {
-	var err error
+	err := htm.writeTableBeginIfMissing()
+	if err != nil {
+		return err
+	}
+	err = htm.checkCellWrapTag()
+	if err != nil {
+		return err
+	}
+	err = htm.openSection("tbody")
+	if err != nil {
+		return err
+	}
+	htm.numCols = max(htm.numCols, len(columnValues))
+
 	if htm.TableConfig.DataRowClass != "" || htm.TableConfig.RowClass != "" {
-		err = htm.write("<tr class='%s'>\n", strings.TrimSpace(htm.TableConfig.DataRowClass+" "+htm.TableConfig.RowClass))
+		err = htm.write("<tr class='%s'>\n", html.EscapeString(strings.TrimSpace(htm.TableConfig.DataRowClass+" "+htm.TableConfig.RowClass)))
 	} else {
 		err = htm.write("<tr>\n")
 	}
@@ -103,23 +280,81 @@ func (htm *HTMLRenderer) RenderRow(columnValues []reflect.Value) error {
 		return err
 	}
 
-	for _, columnValue := range columnValues {
-		str := strfmt.FormatValue(columnValue, htm.txtConfig)
-
-		// if the value does not have its own formatter, escape the resulting string
-		derefType := columnValue.Type()
-		for derefType.Kind() == reflect.Ptr {
-			derefType = derefType.Elem()
-		}
-		if htm.txtConfig.TypeFormatters[derefType] == nil {
-			str = html.EscapeString(str)
+	for col, columnValue := range columnValues {
+		err = htm.write("<td%s>%s</td>", htm.cellAttrs(htm.TableConfig.DataCellClass, col), htm.wrapCellContent(htm.formatValue(columnValue)))
+		if err != nil {
+			return err
 		}
+	}
 
-		if htm.TableConfig.DataCellClass != "" || htm.TableConfig.CellClass != "" {
-			err = htm.write("<td class='%s'>%s</td>", strings.TrimSpace(htm.TableConfig.DataCellClass+" "+htm.TableConfig.CellClass), str)
-		} else {
-			err = htm.write("<td>%s</td>", str)
-		}
+	return htm.write("</tr>\n")
+}
+
+// RenderTextRow renders a row within tbody with a single cell
+// containing the HTML escaped text spanning all columns
+// rendered so far, for example as header row of a group of rows.
+// Implements TextRowRenderer.
+func (htm *HTMLRenderer) RenderTextRow(text string) error {
+	err := htm.writeTableBeginIfMissing()
+	if err != nil {
+		return err
+	}
+	err = htm.openSection("tbody")
+	if err != nil {
+		return err
+	}
+
+	if htm.TableConfig.TextRowClass != "" || htm.TableConfig.RowClass != "" {
+		err = htm.write("<tr class='%s'>\n", html.EscapeString(strings.TrimSpace(htm.TableConfig.TextRowClass+" "+htm.TableConfig.RowClass)))
+	} else {
+		err = htm.write("<tr>\n")
+	}
+	if err != nil {
+		return err
+	}
+	colspan := ""
+	if htm.numCols > 1 {
+		colspan = fmt.Sprintf(" colspan='%d'", htm.numCols)
+	}
+	if htm.TableConfig.CellClass != "" {
+		err = htm.write("<td class='%s'%s>%s</td>", html.EscapeString(htm.TableConfig.CellClass), colspan, html.EscapeString(text))
+	} else {
+		err = htm.write("<td%s>%s</td>", colspan, html.EscapeString(text))
+	}
+	if err != nil {
+		return err
+	}
+	return htm.write("</tr>\n")
+}
+
+// RenderFooterRow renders the columnValues
+// as row within a tfoot element.
+func (htm *HTMLRenderer) RenderFooterRow(columnValues []reflect.Value) error {
+	err := htm.writeTableBeginIfMissing()
+	if err != nil {
+		return err
+	}
+	err = htm.checkCellWrapTag()
+	if err != nil {
+		return err
+	}
+	err = htm.openSection("tfoot")
+	if err != nil {
+		return err
+	}
+	htm.numCols = max(htm.numCols, len(columnValues))
+
+	if htm.TableConfig.FooterRowClass != "" || htm.TableConfig.RowClass != "" {
+		err = htm.write("<tr class='%s'>\n", html.EscapeString(strings.TrimSpace(htm.TableConfig.FooterRowClass+" "+htm.TableConfig.RowClass)))
+	} else {
+		err = htm.write("<tr>\n")
+	}
+	if err != nil {
+		return err
+	}
+
+	for col, columnValue := range columnValues {
+		err = htm.write("<td%s>%s</td>", htm.cellAttrs(htm.TableConfig.FooterCellClass, col), htm.wrapCellContent(htm.formatValue(columnValue)))
 		if err != nil {
 			return err
 		}
@@ -128,8 +363,103 @@ func (htm *HTMLRenderer) RenderRow(columnValues []reflect.Value) error {
 	return htm.write("</tr>\n")
 }
 
+// checkCellWrapTag returns an error if TableConfig.CellWrapTag
+// is not empty and not a valid element name.
+func (htm *HTMLRenderer) checkCellWrapTag() error {
+	tag := htm.TableConfig.CellWrapTag
+	for i, r := range tag {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || i > 0 && (r >= '0' && r <= '9' || r == '-')) {
+			return errs.Errorf("invalid HTML CellWrapTag %q", tag)
+		}
+	}
+	return nil
+}
+
+// wrapCellContent wraps the cell content in an element
+// named TableConfig.CellWrapTag if not empty.
+func (htm *HTMLRenderer) wrapCellContent(content string) string {
+	if htm.TableConfig.CellWrapTag == "" {
+		return content
+	}
+	return "<" + htm.TableConfig.CellWrapTag + ">" + content + "</" + htm.TableConfig.CellWrapTag + ">"
+}
+
+// cellAttrs returns the class attribute combining class
+// with TableConfig.CellClass and the dir attribute
+// from TableConfig.ColumnDirs for the cell at index col.
+func (htm *HTMLRenderer) cellAttrs(class string, col int) string {
+	var attrs string
+	if class := strings.TrimSpace(class + " " + htm.TableConfig.CellClass); class != "" {
+		attrs = fmt.Sprintf(" class='%s'", html.EscapeString(class))
+	}
+	if col < len(htm.TableConfig.ColumnDirs) && htm.TableConfig.ColumnDirs[col] != "" {
+		attrs += fmt.Sprintf(" dir='%s'", html.EscapeString(htm.TableConfig.ColumnDirs[col]))
+	}
+	return attrs
+}
+
+// formatJoinedSlice formats columnValue with FormatJoinedSlice
+// if SliceJoiner is set and the value type is complex
+// without a TypeFormatter.
+func (htm *HTMLRenderer) formatJoinedSlice(columnValue reflect.Value) (string, bool) {
+	if htm.SliceJoiner == "" {
+		return "", false
+	}
+	if columnValue.Kind() == reflect.Interface {
+		columnValue = columnValue.Elem()
+	}
+	if !IsComplexType(columnValue.Type()) || hasTypeFormatter(columnValue.Type(), htm.txtConfig) {
+		return "", false
+	}
+	return FormatJoinedSlice(columnValue, htm.SliceJoiner, htm.txtConfig)
+}
+
+// formatValue formats columnValue as string
+// and escapes it if the value type is not one of RawHTMLTypes.
+func (htm *HTMLRenderer) formatValue(columnValue reflect.Value) string {
+	if IsNull(columnValue) {
+		return html.EscapeString(htm.txtConfig.Nil)
+	}
+	str, ok := htm.formatJoinedSlice(columnValue)
+	if !ok {
+		str = strfmt.FormatValue(columnValue, htm.txtConfig)
+	}
+
+	// Only the output of explicitly trusted types is not escaped
+	derefType := columnValue.Type()
+	for derefType.Kind() == reflect.Ptr {
+		derefType = derefType.Elem()
+	}
+	if !htm.RawHTMLTypes[derefType] {
+		str = html.EscapeString(str)
+	}
+	return str
+}
+
+// Finish closes the table exactly once.
+// Implements Finisher.
+func (htm *HTMLRenderer) Finish() error {
+	if htm.finished {
+		return nil
+	}
+	err := htm.writeTableBeginIfMissing()
+	if err != nil {
+		return err
+	}
+	err = htm.closeSection()
+	if err != nil {
+		return err
+	}
+	_, err = htm.buf.WriteString("</table>\n")
+	if err != nil {
+		return err
+	}
+	htm.finished = true
+	return nil
+}
+
 func (htm *HTMLRenderer) Result() ([]byte, error) {
-	_, err := htm.buf.WriteString("</tbody></table>\n")
+	err := htm.Finish()
 	if err != nil {
 		return nil, err
 	}
@@ -137,7 +467,11 @@ func (htm *HTMLRenderer) Result() ([]byte, error) {
 }
 
 func (htm *HTMLRenderer) WriteResultTo(writer io.Writer) error {
-	_, err := htm.buf.WriteTo(writer)
+	err := htm.Finish()
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(htm.buf.Bytes())
 	return err
 }
 
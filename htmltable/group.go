@@ -0,0 +1,56 @@
+package htmltable
+
+import (
+	"reflect"
+
+	"github.com/domonda/go-structtable"
+)
+
+// RenderGrouped renders the rows of structSlice grouped by
+// the key returned by groupKey for every row with a header row
+// containing the key spanning all columns before the rows of each group.
+// Groups are rendered in the order of the first row of every group
+// and the rows within a group keep their order from structSlice,
+// so structSlice does not have to be sorted by the key.
+func RenderGrouped(renderer *Renderer, structSlice any, renderTitleRow bool, columnMapper structtable.ColumnMapper, groupKey func(row reflect.Value) string) error {
+	rows, err := structtable.ReflectRows(structSlice)
+	if err != nil {
+		return err
+	}
+
+	var (
+		keys   []string
+		groups = make(map[string][]int)
+	)
+	for i := 0; i < rows.Len(); i++ {
+		key := groupKey(rows.Index(i))
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], i)
+	}
+
+	columnTitles, rowReflector := columnMapper.ColumnTitlesAndRowReflector(rows.Type().Elem())
+
+	if renderTitleRow {
+		err := renderer.RenderHeaderRow(columnTitles)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, key := range keys {
+		err := renderer.RenderTextRow(key)
+		if err != nil {
+			return err
+		}
+		for _, i := range groups[key] {
+			err = renderer.RenderRow(rowReflector.ReflectRow(rows.Index(i)))
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"math/rand"
+	"regexp"
 
 	"github.com/domonda/go-structtable"
 	"github.com/domonda/go-types/strfmt"
@@ -13,16 +14,37 @@ type Renderer struct {
 	*structtable.HTMLRenderer
 }
 
+// NewRenderer returns a Renderer with CSS class names
+// derived from a random prefix so that the styles
+// don't collide with other tables in the same document.
 func NewRenderer(caption string, config *strfmt.FormatConfig) *Renderer {
-	r := &Renderer{}
 	//#nosec G404 -- weak random number OK
+	return NewRendererWithPrefix(caption, fmt.Sprintf("c%d", rand.Uint32()), config)
+}
+
+// cssClassPrefix matches prefixes that result in valid CSS class names
+var cssClassPrefix = regexp.MustCompile(`^[A-Za-z_-][A-Za-z0-9_-]*$`)
+
+// NewRendererWithPrefix returns a Renderer with stable CSS class names
+// like prefix+"-table" for reproducible output
+// and multiple known tables in the same document.
+// It panics if prefix is not a valid CSS identifier
+// of ASCII letters, digits, underscores, and hyphens
+// not starting with a digit, because it is written
+// unescaped into the class attributes and the style sheet.
+func NewRendererWithPrefix(caption, prefix string, config *strfmt.FormatConfig) *Renderer {
+	if !cssClassPrefix.MatchString(prefix) {
+		panic(fmt.Sprintf("invalid CSS class prefix %q", prefix))
+	}
+	r := &Renderer{}
 	table := &structtable.HTMLTableConfig{
 		Caption:        caption,
-		CaptionClass:   fmt.Sprintf("c%d", rand.Uint32()),
-		TableClass:     fmt.Sprintf("c%d", rand.Uint32()),
-		CellClass:      fmt.Sprintf("c%d", rand.Uint32()),
-		HeaderRowClass: fmt.Sprintf("c%d", rand.Uint32()),
-		DataRowClass:   fmt.Sprintf("c%d", rand.Uint32()),
+		CaptionClass:   prefix + "-caption",
+		TableClass:     prefix + "-table",
+		CellClass:      prefix + "-cell",
+		HeaderRowClass: prefix + "-header",
+		DataRowClass:   prefix + "-row",
+		TextRowClass:   prefix + "-text",
 	}
 	r.HTMLRenderer = structtable.NewHTMLRenderer(r, table, config)
 	return r
@@ -57,12 +79,17 @@ func (r *Renderer) RenderBeforeTable(writer io.Writer) error {
 			tr.%s:nth-child(even) {
 				background-color: #ffffff
 			}
+			tr.%s {
+				font-weight: bold;
+				background-color: #00000029
+			}
 		</style>`,
 		r.TableConfig.TableClass, r.TableConfig.CellClass, r.TableConfig.CellClass, r.TableConfig.HeaderRowClass,
 		r.TableConfig.CaptionClass,
 		r.TableConfig.HeaderRowClass,
 		r.TableConfig.DataRowClass,
 		r.TableConfig.DataRowClass,
+		r.TableConfig.TextRowClass,
 	)
 	return err
 }
@@ -1 +1,231 @@
 package htmltable
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-structtable"
+	"github.com/domonda/go-types/date"
+	"github.com/domonda/go-types/strfmt"
+)
+
+type testRow struct {
+	Name  string
+	Count int
+}
+
+func TestRender(t *testing.T) {
+	rows := []testRow{{"A", 1}, {"B", 2}}
+
+	var buf bytes.Buffer
+	err := Render(&buf, rows, "Caption", true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result := buf.String()
+
+	assert.Equal(t, 1, strings.Count(result, "<thead>"), "one thead")
+	assert.Equal(t, 1, strings.Count(result, "<tbody>"), "one tbody")
+	assert.Less(t, strings.Index(result, "<th "), strings.Index(result, "</thead>\n<tbody>"), "header row within thead")
+	assert.Less(t, strings.Index(result, "</thead>"), strings.Index(result, "<td "), "data rows after thead")
+	assert.True(t, strings.HasSuffix(result, "</tr>\n</tbody>\n</table>\n"), "tbody closed before table")
+}
+
+func TestRenderEscapesCaptionAndClasses(t *testing.T) {
+	renderer := NewRenderer("A & B <x>", strfmt.NewEnglishFormatConfig())
+	renderer.TableConfig.RowClass = "x'><script>"
+
+	err := structtable.Render(renderer, []testRow{{"A", 1}}, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+
+	assert.Contains(t, string(result), ">A &amp; B &lt;x&gt;</caption>")
+	assert.NotContains(t, string(result), "<x>")
+	assert.NotContains(t, string(result), "<script>")
+}
+
+func TestRenderEscapesColumnTitles(t *testing.T) {
+	renderer := NewRendererWithPrefix("", "t", strfmt.NewEnglishFormatConfig())
+
+	err := structtable.Render(renderer, []testRow{{"A", 1}}, true, structtable.ColumnTitles{"A & B", "<script>"})
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+
+	assert.Contains(t, string(result), "<th class='t-cell'>A &amp; B</th><th class='t-cell'>&lt;script&gt;</th>")
+	assert.NotContains(t, string(result), "<script>")
+}
+
+func TestRenderColumnWidths(t *testing.T) {
+	renderer := NewRenderer("", strfmt.NewEnglishFormatConfig())
+	renderer.TableConfig.ColumnWidths = []string{"10em"}
+
+	err := structtable.Render(renderer, []testRow{{"A", 1}}, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+
+	assert.Contains(t, string(result), "<colgroup><col style='width:10em'><col></colgroup>\n<thead>")
+}
+
+func TestRenderDir(t *testing.T) {
+	renderer := NewRendererWithPrefix("", "t", strfmt.NewEnglishFormatConfig())
+	renderer.TableConfig.Dir = "rtl"
+	renderer.TableConfig.ColumnDirs = []string{"", "ltr"}
+
+	err := structtable.Render(renderer, []testRow{{"A", 1}}, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+
+	assert.Contains(t, string(result), "<table class='t-table' dir='rtl'>")
+	assert.Contains(t, string(result), "<th class='t-cell'>Name</th><th class='t-cell' dir='ltr'>Count</th>")
+	assert.Contains(t, string(result), "<td class='t-cell'>A</td><td class='t-cell' dir='ltr'>1</td>")
+}
+
+func TestRenderEscapesTypeFormatterOutput(t *testing.T) {
+	type comment string
+	type row struct {
+		Comment comment
+	}
+	config := strfmt.NewEnglishFormatConfig()
+	config.TypeFormatters[reflect.TypeOf(comment(""))] = strfmt.FormatterFunc(func(val reflect.Value, config *strfmt.FormatConfig) string {
+		return "<em>" + val.String() + "</em>"
+	})
+	rows := []row{{"<script>alert(1)</script>"}}
+
+	renderer := NewRenderer("", config)
+	err := structtable.Render(renderer, rows, false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Contains(t, string(result), "&lt;em&gt;&lt;script&gt;alert(1)&lt;/script&gt;&lt;/em&gt;", "untrusted formatter output escaped")
+	assert.NotContains(t, string(result), "<script>")
+
+	renderer = NewRenderer("", config)
+	renderer.RawHTMLTypes = map[reflect.Type]bool{reflect.TypeOf(comment("")): true}
+	err = structtable.Render(renderer, rows, false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err = renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Contains(t, string(result), "<em><script>alert(1)</script></em>", "trusted type not escaped")
+}
+
+func TestRenderGrouped(t *testing.T) {
+	type transaction struct {
+		Date   date.Date
+		Amount float64
+	}
+	transactions := []transaction{
+		{"2024-01-05", 10},
+		{"2024-02-01", 20},
+		{"2024-01-20", 30},
+	}
+	byMonth := func(row reflect.Value) string {
+		return row.Interface().(transaction).Date.Format("January 2006")
+	}
+
+	renderer := NewRenderer("", strfmt.NewEnglishFormatConfig())
+	err := RenderGrouped(renderer, transactions, true, structtable.DefaultReflectColumnTitles, byMonth)
+	assert.NoError(t, err, "RenderGrouped")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	html := string(result)
+
+	january := strings.Index(html, "colspan='2'>January 2024</td>")
+	february := strings.Index(html, "colspan='2'>February 2024</td>")
+	assert.Greater(t, january, strings.Index(html, "</thead>"), "group rows after header")
+	assert.Greater(t, february, january, "groups in order of first row")
+	assert.Less(t, strings.Index(html, ">30</td>"), february, "January rows grouped before February")
+	assert.Greater(t, strings.Index(html, ">20</td>"), february, "February row after its group row")
+}
+
+func TestRenderResultTwice(t *testing.T) {
+	renderer := NewRenderer("", strfmt.NewEnglishFormatConfig())
+	err := structtable.Render(renderer, []testRow{{"A", 1}}, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+
+	first, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	second, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	var buf bytes.Buffer
+	err = renderer.WriteResultTo(&buf)
+	assert.NoError(t, err, "WriteResultTo")
+
+	assert.Equal(t, 1, strings.Count(string(second), "</table>"), "single closing tag")
+	assert.Equal(t, string(first), string(second))
+	assert.Equal(t, string(first), buf.String())
+}
+
+func TestNewRendererWithPrefix(t *testing.T) {
+	render := func() string {
+		renderer := NewRendererWithPrefix("", "t1", strfmt.NewEnglishFormatConfig())
+		err := structtable.Render(renderer, []testRow{{"A", 1}}, true, structtable.DefaultReflectColumnTitles)
+		assert.NoError(t, err, "Render")
+		result, err := renderer.Result()
+		assert.NoError(t, err, "Result")
+		return string(result)
+	}
+	result := render()
+
+	assert.Equal(t, result, render(), "reproducible output")
+	assert.Contains(t, result, "<table class='t1-table'>")
+	assert.Contains(t, result, "<tr class='t1-header'>\n<th class='t1-cell'>Name</th>")
+	assert.Contains(t, result, "tr.t1-row:nth-child(odd)")
+
+	for _, prefix := range []string{"", "1t", "t 1", "t'><script>", "t{}"} {
+		assert.Panics(t, func() { NewRendererWithPrefix("", prefix, strfmt.NewEnglishFormatConfig()) }, "prefix %q", prefix)
+	}
+	assert.NotPanics(t, func() { NewRendererWithPrefix("", "_my-table_2", strfmt.NewEnglishFormatConfig()) })
+}
+
+func TestRenderSliceJoiner(t *testing.T) {
+	type row struct {
+		Tags []string
+	}
+	renderer := NewRenderer("", strfmt.NewEnglishFormatConfig())
+	renderer.SliceJoiner = " | "
+	err := structtable.Render(renderer, []row{{[]string{"a", "<b>"}}}, false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Contains(t, string(result), ">a | &lt;b&gt;</td>")
+}
+
+func TestRenderWrapHeaders(t *testing.T) {
+	renderer := NewRendererWithPrefix("", "t", strfmt.NewEnglishFormatConfig())
+	renderer.TableConfig.WrapHeaders = true
+
+	err := structtable.Render(renderer, []testRow{{"A", 1}}, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+
+	assert.Contains(t, string(result), "<th class='t-cell' style='white-space:normal'>Name</th>")
+	assert.Contains(t, string(result), "<td class='t-cell'>A</td>", "data cells not wrapped")
+}
+
+func TestRenderCellWrapTag(t *testing.T) {
+	renderer := NewRendererWithPrefix("", "t", strfmt.NewEnglishFormatConfig())
+	renderer.TableConfig.CellWrapTag = "span"
+
+	err := structtable.RenderWithFooter(renderer, []testRow{{"A&B", 1}}, true, structtable.DefaultReflectColumnTitles, func(any) []reflect.Value {
+		return []reflect.Value{reflect.ValueOf("Total"), reflect.ValueOf(1)}
+	})
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+
+	assert.Contains(t, string(result), "<th class='t-cell'>Name</th>", "header cells not wrapped")
+	assert.Contains(t, string(result), "<td class='t-cell'><span>A&amp;B</span></td><td class='t-cell'><span>1</span></td>")
+	assert.Contains(t, string(result), "<span>Total</span>", "footer cells wrapped")
+
+	renderer = NewRenderer("", strfmt.NewEnglishFormatConfig())
+	renderer.TableConfig.CellWrapTag = "span onclick='x'"
+	err = structtable.Render(renderer, []testRow{{"A", 1}}, false, structtable.DefaultReflectColumnTitles)
+	assert.Error(t, err, "invalid tag name")
+}
@@ -0,0 +1,149 @@
+package jsontable
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+
+	fs "github.com/ungerik/go-fs"
+
+	"github.com/domonda/go-structtable"
+)
+
+// NDJSONRenderer implements structtable.Renderer by rendering
+// every row as standalone JSON object on its own line
+// (newline delimited JSON) without an enclosing array.
+//
+// The keys of the objects are the column titles of the header row
+// or the column indices as strings if no header row was rendered.
+// Values are rendered as native JSON types where possible,
+// null values as defined by structtable.IsNull as JSON null.
+//
+// NDJSONRenderer is not safe for concurrent use,
+// call Reset to reuse it for another table.
+type NDJSONRenderer struct {
+	keys [][]byte
+	buf  bytes.Buffer
+	// stream is written to directly if not nil
+	stream io.Writer
+}
+
+// NewNDJSONRenderer returns a NDJSONRenderer that buffers
+// the rendered lines until the result is requested.
+func NewNDJSONRenderer() *NDJSONRenderer {
+	return new(NDJSONRenderer)
+}
+
+// NewNDJSONStreamRenderer returns a NDJSONRenderer that writes
+// every rendered row directly to writer without buffering,
+// so that huge tables can be streamed object by object.
+// Result and WriteResultTo return no data for a stream renderer.
+func NewNDJSONStreamRenderer(writer io.Writer) *NDJSONRenderer {
+	return &NDJSONRenderer{stream: writer}
+}
+
+// RenderHeaderRow uses the columnTitles as keys of the objects
+func (nd *NDJSONRenderer) RenderHeaderRow(columnTitles []string) error {
+	nd.keys = make([][]byte, len(columnTitles))
+	for i, title := range columnTitles {
+		key, err := json.Marshal(title)
+		if err != nil {
+			return err
+		}
+		nd.keys[i] = key
+	}
+	return nil
+}
+
+func (nd *NDJSONRenderer) RenderRow(columnValues []reflect.Value) error {
+	var line bytes.Buffer
+	line.WriteByte('{')
+	for i, val := range columnValues {
+		if i > 0 {
+			line.WriteByte(',')
+		}
+		if i < len(nd.keys) {
+			line.Write(nd.keys[i])
+		} else {
+			line.WriteString(strconv.Quote(strconv.Itoa(i)))
+		}
+		line.WriteByte(':')
+		value, err := MarshalValue(val)
+		if err != nil {
+			return err
+		}
+		line.Write(value)
+	}
+	line.WriteString("}\n")
+	if nd.stream != nil {
+		_, err := nd.stream.Write(line.Bytes())
+		return err
+	}
+	_, err := nd.buf.Write(line.Bytes())
+	return err
+}
+
+// RenderFooterRow is a no-op because
+// footer rows are not supported by NDJSON.
+func (*NDJSONRenderer) RenderFooterRow(columnValues []reflect.Value) error {
+	return nil
+}
+
+// Reset clears the rendered lines and keys
+// so that the NDJSONRenderer can be reused.
+func (nd *NDJSONRenderer) Reset() {
+	nd.keys = nil
+	nd.buf.Reset()
+}
+
+func (nd *NDJSONRenderer) Result() ([]byte, error) {
+	return nd.buf.Bytes(), nil
+}
+
+func (nd *NDJSONRenderer) WriteResultTo(writer io.Writer) error {
+	_, err := writer.Write(nd.buf.Bytes())
+	return err
+}
+
+func (nd *NDJSONRenderer) WriteResultFile(file fs.File, perm ...fs.Permissions) error {
+	writer, err := file.OpenWriter(perm...)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	return nd.WriteResultTo(writer)
+}
+
+func (*NDJSONRenderer) MIMEType() string {
+	return "application/x-ndjson"
+}
+
+// MarshalValue returns val marshalled as JSON.
+// Null values as defined by structtable.IsNull are returned as null,
+// NaN and infinite floats as strings because JSON has no numbers for them,
+// and values that can't be marshalled as JSON as strings
+// formatted with fmt.Sprint.
+func MarshalValue(val reflect.Value) ([]byte, error) {
+	if structtable.IsNull(val) {
+		return []byte("null"), nil
+	}
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		val = val.Elem()
+	}
+	switch val.Kind() {
+	case reflect.Float32, reflect.Float64:
+		if f := val.Float(); math.IsNaN(f) || math.IsInf(f, 0) {
+			return json.Marshal(fmt.Sprint(f))
+		}
+	}
+	data, err := json.Marshal(val.Interface())
+	if err != nil {
+		return json.Marshal(fmt.Sprint(val.Interface()))
+	}
+	return data, nil
+}
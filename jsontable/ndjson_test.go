@@ -0,0 +1,51 @@
+package jsontable
+
+import (
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-structtable"
+)
+
+func TestNDJSONRenderer(t *testing.T) {
+	type row struct {
+		Name  string
+		Count int
+		Price *float64
+		Valid bool
+		Time  time.Time
+	}
+	price := 1.5
+	rows := []row{
+		{Name: "A \"quoted\"", Count: 1, Price: &price, Valid: true, Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{Name: "B", Count: 2},
+	}
+
+	renderer := NewNDJSONRenderer()
+	err := structtable.Render(renderer, rows, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+
+	const expected = `{"Name":"A \"quoted\"","Count":1,"Price":1.5,"Valid":true,"Time":"2024-01-02T03:04:05Z"}
+{"Name":"B","Count":2,"Price":null,"Valid":false,"Time":null}
+`
+	assert.Equal(t, expected, string(result))
+	assert.Equal(t, "application/x-ndjson", renderer.MIMEType())
+
+	renderer.Reset()
+	err = structtable.Render(renderer, []struct{ F float64 }{{math.NaN()}}, false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err = renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, `{"0":"NaN"}`+"\n", string(result))
+
+	var stream strings.Builder
+	err = structtable.Render(NewNDJSONStreamRenderer(&stream), rows[1:], true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	assert.Equal(t, expected[strings.IndexByte(expected, '\n')+1:], stream.String())
+}
@@ -0,0 +1,239 @@
+package jsontable
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"reflect"
+	"strings"
+
+	"github.com/domonda/go-errs"
+	"github.com/domonda/go-types/strfmt"
+	fs "github.com/ungerik/go-fs"
+
+	"github.com/domonda/go-structtable"
+)
+
+// Reader implements structtable.Reader for a JSON array of objects
+// where every object is a row.
+//
+// The columns are the union of the keys of all objects
+// in the order of their first occurrence,
+// missing keys and null values are read as empty strings.
+// There are no header rows, see Columns for the keys.
+//
+// ReadRow sets the struct fields named by their "col" tag,
+// or "json" tag, or their name to the value of the matching key.
+// Keys are matched case sensitive first, then case insensitive.
+// String values are parsed with strfmt.Scan, other values
+// are unmarshalled as JSON with a fallback to strfmt.Scan
+// of their JSON text.
+type Reader struct {
+	ScanConfig *strfmt.ScanConfig `json:"config"`
+
+	columns []string
+	objects []map[string]json.RawMessage
+}
+
+// NewReader reads a JSON array of objects from an io.Reader
+func NewReader(reader io.Reader, scanConfig ...*strfmt.ScanConfig) (r *Reader, err error) {
+	defer errs.WrapWithFuncParams(&err, reader, scanConfig)
+
+	r = &Reader{ScanConfig: strfmt.DefaultScanConfig}
+	if len(scanConfig) > 0 && scanConfig[0] != nil {
+		r.ScanConfig = scanConfig[0]
+	}
+
+	dec := json.NewDecoder(reader)
+	err = expectDelim(dec, '[')
+	if err != nil {
+		return nil, err
+	}
+	hasColumn := make(map[string]bool)
+	for dec.More() {
+		err = expectDelim(dec, '{')
+		if err != nil {
+			return nil, err
+		}
+		object := make(map[string]json.RawMessage)
+		for dec.More() {
+			token, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key := token.(string) // Object keys are always strings
+			var value json.RawMessage
+			err = dec.Decode(&value)
+			if err != nil {
+				return nil, err
+			}
+			object[key] = value
+			if !hasColumn[key] {
+				hasColumn[key] = true
+				r.columns = append(r.columns, key)
+			}
+		}
+		err = expectDelim(dec, '}')
+		if err != nil {
+			return nil, err
+		}
+		r.objects = append(r.objects, object)
+	}
+	err = expectDelim(dec, ']')
+	if err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// NewReaderFromFile reads a JSON array of objects from a fs.FileReader
+func NewReaderFromFile(file fs.FileReader, scanConfig ...*strfmt.ScanConfig) (r *Reader, err error) {
+	defer errs.WrapWithFuncParams(&err, file, scanConfig)
+
+	reader, err := file.OpenReader()
+	if err != nil {
+		return nil, err
+	}
+	defer reader.Close()
+
+	return NewReader(reader, scanConfig...)
+}
+
+func expectDelim(dec *json.Decoder, delim json.Delim) error {
+	token, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if token != delim {
+		return errs.Errorf("expected JSON %q but got %v", delim, token)
+	}
+	return nil
+}
+
+// Columns returns the union of the keys of all objects
+// in the order of their first occurrence.
+func (r *Reader) Columns() []string {
+	return r.columns
+}
+
+func (r *Reader) NumRows() int {
+	return len(r.objects)
+}
+
+// ReadRowStrings returns the values of the object with the passed index
+// in the order of Columns.
+// Strings are returned unquoted, missing keys and null as empty strings,
+// and all other values as JSON.
+func (r *Reader) ReadRowStrings(index int) ([]string, error) {
+	if index < 0 || index >= len(r.objects) {
+		return nil, errs.Errorf("row index %d out of bounds [0..%d)", index, len(r.objects))
+	}
+	object := r.objects[index]
+	row := make([]string, len(r.columns))
+	for i, key := range r.columns {
+		row[i] = valueString(object[key])
+	}
+	return row, nil
+}
+
+func (r *Reader) ReadAllStrings() ([][]string, error) {
+	return structtable.ReadAllRowStrings(r)
+}
+
+func (r *Reader) ReadRow(index int, destStruct reflect.Value) error {
+	if index < 0 || index >= len(r.objects) {
+		return errs.Errorf("row index %d out of bounds [0..%d)", index, len(r.objects))
+	}
+	object := r.objects[index]
+	structType := destStruct.Type()
+	fields := structtable.StructFieldTypes(structType)
+	indices := structtable.StructFieldIndices(structType)
+	for i, field := range fields {
+		key := fieldKey(field)
+		if key == "" {
+			continue
+		}
+		value, ok := r.lookup(object, key)
+		if !ok || isNull(value) {
+			continue
+		}
+		destStructField, err := destStruct.FieldByIndexErr(indices[i])
+		if err != nil {
+			// Field of nil embedded struct pointer
+			continue
+		}
+		err = r.scan(destStructField, value)
+		if err != nil {
+			return errs.Errorf("error parsing row %d, key %q value %s: %w", index, key, value, err)
+		}
+	}
+	return nil
+}
+
+// lookup returns the value of key from object,
+// or of the first column matching key case insensitive.
+func (r *Reader) lookup(object map[string]json.RawMessage, key string) (json.RawMessage, bool) {
+	if value, ok := object[key]; ok {
+		return value, true
+	}
+	for _, column := range r.columns {
+		if strings.EqualFold(column, key) {
+			if value, ok := object[column]; ok {
+				return value, true
+			}
+		}
+	}
+	return nil, false
+}
+
+func (r *Reader) scan(dest reflect.Value, value json.RawMessage) error {
+	if value[0] == '"' {
+		var str string
+		err := json.Unmarshal(value, &str)
+		if err != nil {
+			return err
+		}
+		return strfmt.Scan(dest, str, r.ScanConfig)
+	}
+	err := json.Unmarshal(value, dest.Addr().Interface())
+	if err == nil {
+		return nil
+	}
+	return strfmt.Scan(dest, string(value), r.ScanConfig)
+}
+
+// fieldKey returns the JSON key for a struct field
+// or an empty string if the field is ignored.
+func fieldKey(field reflect.StructField) string {
+	for _, tag := range []string{"col", "json"} {
+		name, ok := field.Tag.Lookup(tag)
+		if !ok {
+			continue
+		}
+		name, _, _ = strings.Cut(name, ",")
+		if name == "-" {
+			return ""
+		}
+		if name != "" {
+			return name
+		}
+	}
+	return field.Name
+}
+
+func isNull(value json.RawMessage) bool {
+	return len(value) == 0 || bytes.Equal(value, []byte("null"))
+}
+
+func valueString(value json.RawMessage) string {
+	if isNull(value) {
+		return ""
+	}
+	if value[0] == '"' {
+		var str string
+		if json.Unmarshal(value, &str) == nil {
+			return str
+		}
+	}
+	return string(value)
+}
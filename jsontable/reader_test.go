@@ -0,0 +1,52 @@
+package jsontable
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/domonda/go-structtable"
+	"github.com/domonda/go-types/date"
+)
+
+func TestReader(t *testing.T) {
+	const data = `[
+		{"name": "Apple", "count": 3, "tags": ["red"], "date": "2024-03-01"},
+		{"count": null, "name": "Pear", "extra": {"a": 1}},
+		{"NAME": "Plum", "Count": "7"}
+	]`
+	reader, err := NewReader(strings.NewReader(data))
+	require.NoError(t, err, "NewReader")
+	assert.Equal(t, []string{"name", "count", "tags", "date", "extra", "NAME", "Count"}, reader.Columns())
+
+	rows, err := reader.ReadAllStrings()
+	require.NoError(t, err, "ReadAllStrings")
+	assert.Equal(t, [][]string{
+		{"Apple", "3", `["red"]`, "2024-03-01", "", "", ""},
+		{"Pear", "", "", "", `{"a": 1}`, "", ""},
+		{"", "", "", "", "", "Plum", "7"},
+	}, rows)
+
+	type row struct {
+		Name    string    `json:"name"`
+		Count   int       `col:"count"`
+		Tags    []string  `json:"tags,omitempty"`
+		Date    date.Date `json:"date"`
+		Ignored string    `json:"-"`
+	}
+	var structs []row
+	_, err = structtable.Read(reader, &structs, 0)
+	require.NoError(t, err, "Read")
+	assert.Equal(t, []row{
+		{Name: "Apple", Count: 3, Tags: []string{"red"}, Date: "2024-03-01"},
+		{Name: "Pear"},
+		{Name: "Plum", Count: 7},
+	}, structs)
+
+	_, err = NewReader(strings.NewReader(`{"name": "Apple"}`))
+	assert.Error(t, err, "no array")
+	_, err = NewReader(strings.NewReader(`[1, 2]`))
+	assert.Error(t, err, "no objects")
+}
@@ -0,0 +1,109 @@
+package kvtable
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/domonda/go-structtable"
+	"github.com/domonda/go-types/strfmt"
+)
+
+// Renderer implements structtable.Renderer by rendering
+// every row as block of "key = value" lines
+// like in TOML or INI files, for example to export
+// a single struct as human editable config snapshot.
+// Blocks of multiple rows are separated by an empty line.
+//
+// The keys are the column titles of the header row.
+// Without a header row the keys are "column1", "column2", and so on.
+// Values are formatted with the text formatters of the FormatConfig.
+// Keys and values containing special characters are quoted, see Quote.
+//
+// Renderer is not safe for concurrent use,
+// call Reset to reuse it for another table.
+type Renderer struct {
+	*structtable.TextRenderer
+
+	keys    []string
+	numRows int
+}
+
+func NewRenderer(config *strfmt.FormatConfig) *Renderer {
+	kv := new(Renderer)
+	kv.TextRenderer = structtable.NewTextRenderer(kv, config)
+	return kv
+}
+
+// Reset clears the rendered text and keys
+// so that the Renderer can be reused for another table.
+func (kv *Renderer) Reset() {
+	kv.TextRenderer.Reset()
+	kv.keys = nil
+	kv.numRows = 0
+}
+
+func (*Renderer) RenderBeginTableText(writer io.Writer) error {
+	return nil
+}
+
+func (kv *Renderer) RenderHeaderRowText(writer io.Writer, columnTitles []string) error {
+	kv.keys = make([]string, len(columnTitles))
+	for i, title := range columnTitles {
+		kv.keys[i] = QuoteKey(title)
+	}
+	return nil
+}
+
+func (kv *Renderer) RenderRowText(writer io.Writer, fields []string) error {
+	var b strings.Builder
+	if kv.numRows > 0 {
+		b.WriteByte('\n')
+	}
+	for i, field := range fields {
+		key := fmt.Sprintf("column%d", i+1)
+		if i < len(kv.keys) {
+			key = kv.keys[i]
+		}
+		fmt.Fprintf(&b, "%s = %s\n", key, Quote(field))
+	}
+	kv.numRows++
+	_, err := io.WriteString(writer, b.String())
+	return err
+}
+
+func (*Renderer) RenderEndTableText(writer io.Writer) error {
+	return nil
+}
+
+func (*Renderer) MIMEType() string {
+	return "text/plain; charset=UTF-8"
+}
+
+// Quote returns value as double quoted string with Go escaping
+// if it is empty, has leading or trailing whitespace,
+// or contains quotes, comment or section characters,
+// equal signs, backslashes, or control characters.
+// Other values are returned unchanged.
+func Quote(value string) string {
+	if value == "" || strings.TrimSpace(value) != value ||
+		strings.ContainsAny(value, "\"'=#;[]\\") ||
+		strings.ContainsFunc(value, unicode.IsControl) {
+		return strconv.Quote(value)
+	}
+	return value
+}
+
+// QuoteKey returns key as double quoted string with Go escaping
+// if it contains other characters than ASCII letters,
+// digits, underscores, and dashes.
+func QuoteKey(key string) string {
+	if key == "" || strings.ContainsFunc(key, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '_' || r == '-')
+	}) {
+		return strconv.Quote(key)
+	}
+	return key
+}
@@ -0,0 +1,48 @@
+package kvtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-structtable"
+	"github.com/domonda/go-types/strfmt"
+)
+
+func TestRenderer(t *testing.T) {
+	type config struct {
+		Host    string
+		Port    int
+		Comment string `col:"Admin Comment"`
+		Empty   string
+	}
+	rows := []config{
+		{"example.com", 8080, `say "hi" # now`, ""},
+		{"localhost", 80, "line 1\nline 2", "x"},
+	}
+
+	renderer := NewRenderer(strfmt.NewFormatConfig())
+	err := structtable.Render(renderer, rows, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+
+	const expected = `Host = example.com
+Port = 8080
+"Admin Comment" = "say \"hi\" # now"
+Empty = ""
+
+Host = localhost
+Port = 80
+"Admin Comment" = "line 1\nline 2"
+Empty = x
+`
+	assert.Equal(t, expected, string(result))
+
+	renderer.Reset()
+	err = structtable.Render(renderer, rows[:1], false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err = renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, "column1 = example.com\ncolumn2 = 8080\ncolumn3 = \"say \\\"hi\\\" # now\"\ncolumn4 = \"\"\n", string(result))
+}
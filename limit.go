@@ -0,0 +1,58 @@
+package structtable
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/domonda/go-errs"
+)
+
+// DefaultMoreRowsFormat is a format for RenderLimit
+// with the number of omitted rows as argument.
+const DefaultMoreRowsFormat = "… %d more rows"
+
+// TextRowRenderer is implemented by renderers that can render
+// a row with a single text spanning all columns,
+// like a styled merged row in Excel.
+type TextRowRenderer interface {
+	RenderTextRow(text string) error
+}
+
+// RenderLimit renders like Render but stops after limit data rows,
+// for example to render a preview of a large table.
+//
+// If moreRowsFormat is not empty and rows have been omitted,
+// then an indicator row is rendered as last row with the text
+// of moreRowsFormat formatted with the number of omitted rows
+// as argument, see DefaultMoreRowsFormat.
+// If renderer implements TextRowRenderer then the indicator row
+// is rendered with RenderTextRow, else as row with the text
+// in the first column.
+func RenderLimit(renderer Renderer, structSlice any, renderTitleRow bool, columnMapper ColumnMapper, limit int, moreRowsFormat string) error {
+	if limit < 0 {
+		return errs.New("limit can't be negative")
+	}
+	rows, err := ReflectRows(structSlice)
+	if err != nil {
+		return err
+	}
+
+	columnTitles, rowReflector := columnMapper.ColumnTitlesAndRowReflector(rows.Type().Elem())
+
+	err = renderRows(renderer, columnTitles, renderTitleRow, reflectRows(rows, 0, min(limit, rows.Len()), rowReflector), nil)
+	if err != nil {
+		return err
+	}
+
+	numMore := rows.Len() - limit
+	if moreRowsFormat == "" || numMore <= 0 {
+		return nil
+	}
+	text := fmt.Sprintf(moreRowsFormat, numMore)
+	if textRowRenderer, ok := renderer.(TextRowRenderer); ok {
+		return textRowRenderer.RenderTextRow(text)
+	}
+	columnValues := make([]reflect.Value, max(len(columnTitles), 1))
+	columnValues[0] = reflect.ValueOf(text)
+	return renderer.RenderRow(columnValues)
+}
@@ -0,0 +1,41 @@
+package structtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type textRowRecordingRenderer struct {
+	recordingRenderer
+	textRows []string
+}
+
+func (r *textRowRecordingRenderer) RenderTextRow(text string) error {
+	r.textRows = append(r.textRows, text)
+	return nil
+}
+
+func TestRenderLimit(t *testing.T) {
+	rows := []renderTestRow{{A: "a1", B: "b1"}, {A: "a2", B: "b2"}, {A: "a3", B: "b3"}}
+
+	r := new(recordingRenderer)
+	err := RenderLimit(r, rows, true, DefaultReflectColumnTitles, 1, DefaultMoreRowsFormat)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A", "Bee"}, r.header)
+	assert.Equal(t, [][]string{{"a1", "b1"}, {"… 2 more rows", "<invalid Value>"}}, r.rows)
+
+	r = new(recordingRenderer)
+	err = RenderLimit(r, rows, false, DefaultReflectColumnTitles, 3, DefaultMoreRowsFormat)
+	assert.NoError(t, err)
+	assert.Len(t, r.rows, 3, "no indicator row if nothing omitted")
+
+	tr := new(textRowRecordingRenderer)
+	err = RenderLimit(tr, rows, false, DefaultReflectColumnTitles, 2, "%d more")
+	assert.NoError(t, err)
+	assert.Len(t, tr.rows, 2)
+	assert.Equal(t, []string{"1 more"}, tr.textRows)
+
+	err = RenderLimit(r, rows, false, DefaultReflectColumnTitles, -1, "")
+	assert.Error(t, err, "negative limit")
+}
@@ -0,0 +1,127 @@
+package structtable
+
+import (
+	"golang.org/x/text/language"
+
+	"github.com/domonda/go-types/float"
+	"github.com/domonda/go-types/strfmt"
+)
+
+// Locale holds the locale specific formats
+// used by NewTextFormatConfigForLocale.
+type Locale struct {
+	// ThousandsSep groups the digits of floats and money amounts
+	ThousandsSep rune
+	// DecimalSep separates the fraction of floats and money amounts
+	DecimalSep rune
+	// Date is the layout for dates
+	Date string
+	// Time is the layout for times
+	Time string
+	// True is the word for true
+	True string
+	// False is the word for false
+	False string
+}
+
+// Locales are the locales used by NewTextFormatConfigForLocale
+// by language tag like "en-US" or base language like "en".
+// Further locales can be added at program initialization.
+//
+// The base language "en" is also used for unknown tags,
+// so it uses ISO 8601 dates that can't be misread
+// as either day-first or month-first.
+var Locales = map[string]Locale{
+	"en": {
+		ThousandsSep: ',',
+		DecimalSep:   '.',
+		Date:         "2006-01-02",
+		Time:         "2006-01-02 15:04:05 MST",
+		True:         "yes",
+		False:        "no",
+	},
+	"en-GB": {
+		ThousandsSep: ',',
+		DecimalSep:   '.',
+		Date:         "02/01/2006",
+		Time:         "02/01/2006 15:04:05 MST",
+		True:         "yes",
+		False:        "no",
+	},
+	"en-US": {
+		ThousandsSep: ',',
+		DecimalSep:   '.',
+		Date:         "01/02/2006",
+		Time:         "01/02/2006 15:04:05 MST",
+		True:         "yes",
+		False:        "no",
+	},
+	"de": {
+		ThousandsSep: '.',
+		DecimalSep:   ',',
+		Date:         "02.01.2006",
+		Time:         "02.01.2006 15:04:05 MST",
+		True:         "ja",
+		False:        "nein",
+	},
+	"fr": {
+		ThousandsSep: ' ',
+		DecimalSep:   ',',
+		Date:         "02/01/2006",
+		Time:         "02/01/2006 15:04:05 MST",
+		True:         "oui",
+		False:        "non",
+	},
+	"es": {
+		ThousandsSep: '.',
+		DecimalSep:   ',',
+		Date:         "02/01/2006",
+		Time:         "02/01/2006 15:04:05 MST",
+		True:         "sí",
+		False:        "no",
+	},
+	"it": {
+		ThousandsSep: '.',
+		DecimalSep:   ',',
+		Date:         "02/01/2006",
+		Time:         "02/01/2006 15:04:05 MST",
+		True:         "sì",
+		False:        "no",
+	},
+}
+
+// NewTextFormatConfigForLocale returns a strfmt.FormatConfig
+// with the number separators, date and time layouts,
+// and boolean words of the Locale for tag.
+// The Locale is looked up in Locales by the complete tag
+// like "en-US" first and then by its base language like "en".
+// English is used for unknown tags.
+func NewTextFormatConfigForLocale(tag language.Tag) *strfmt.FormatConfig {
+	locale, ok := Locales[tag.String()]
+	if !ok {
+		base, _ := tag.Base()
+		locale, ok = Locales[base.String()]
+		if !ok {
+			locale = Locales["en"]
+		}
+	}
+	floatFormat := float.FormatDef{
+		ThousandsSep: locale.ThousandsSep,
+		DecimalSep:   locale.DecimalSep,
+		Precision:    -1,
+	}
+	config := strfmt.NewFormatConfig()
+	config.Float = floatFormat
+	config.Percent = floatFormat
+	config.MoneyAmount = strfmt.MoneyFormat{
+		CurrencyFirst: true,
+		ThousandsSep:  locale.ThousandsSep,
+		DecimalSep:    locale.DecimalSep,
+		Precision:     2,
+	}
+	config.Date = locale.Date
+	config.Time = locale.Time
+	config.True = locale.True
+	config.False = locale.False
+	return config
+}
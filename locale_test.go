@@ -0,0 +1,44 @@
+package structtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/domonda/go-types/date"
+	"github.com/domonda/go-types/money"
+)
+
+func TestNewTextFormatConfigForLocale(t *testing.T) {
+	type row struct {
+		Float  float64
+		Amount money.Amount
+		Date   date.Date
+		Bool   bool
+	}
+	rows := []row{{1234.5, 1234.5, "2024-01-31", true}}
+
+	tests := []struct {
+		tag  language.Tag
+		want string
+	}{
+		{language.English, "1,234.5,1,234.50,2024-01-31,yes\n"},
+		{language.AmericanEnglish, "1,234.5,1,234.50,01/31/2024,yes\n"},
+		{language.BritishEnglish, "1,234.5,1,234.50,31/01/2024,yes\n"},
+		{language.German, "1.234,5,1.234,50,31.01.2024,ja\n"},
+		{language.MustParse("de-AT"), "1.234,5,1.234,50,31.01.2024,ja\n"},
+		{language.French, "1 234,5,1 234,50,31/01/2024,oui\n"},
+		{language.Spanish, "1.234,5,1.234,50,31/01/2024,sí\n"},
+		{language.Italian, "1.234,5,1.234,50,31/01/2024,sì\n"},
+		{language.Japanese, "1,234.5,1,234.50,2024-01-31,yes\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.tag.String(), func(t *testing.T) {
+			txt := newJoinRenderer(NewTextFormatConfigForLocale(tt.tag))
+			result, err := RenderBytes(txt, rows, false, DefaultReflectColumnTitles)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, string(result))
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package structtable
+
+import (
+	"reflect"
+	"time"
+
+	"github.com/domonda/go-types/nullable"
+)
+
+var (
+	timeType         = reflect.TypeOf(time.Time{})
+	nullableTimeType = reflect.TypeOf(nullable.Time{})
+)
+
+// TimeInLocation returns val converted to loc
+// if val is a time.Time or non null nullable.Time
+// or a pointer to one of them.
+// Other values and a nil loc return val unchanged.
+func TimeInLocation(val reflect.Value, loc *time.Location) reflect.Value {
+	if loc == nil || IsNull(val) {
+		return val
+	}
+	derefVal := val
+	for derefVal.Kind() == reflect.Ptr || derefVal.Kind() == reflect.Interface {
+		derefVal = derefVal.Elem()
+	}
+	switch derefVal.Type() {
+	case timeType:
+		return reflect.ValueOf(derefVal.Interface().(time.Time).In(loc))
+	case nullableTimeType:
+		return reflect.ValueOf(nullable.TimeFrom(derefVal.Interface().(nullable.Time).Get().In(loc)))
+	}
+	return val
+}
@@ -0,0 +1,74 @@
+package structtable
+
+import (
+	"reflect"
+
+	"github.com/domonda/go-types/nullable"
+)
+
+// IsNull returns true if val has to be rendered as null value.
+// This is the case for the zero reflect.Value, nil pointers,
+// interfaces, maps, and slices, values implementing
+// nullable.Nullable that return true from IsNull,
+// and values implementing nullable.Zeroable that
+// return true from IsZero like time.Time, date.Date,
+// or date.NullableDate.
+// Pointers and interfaces are dereferenced
+// before checking the value they point to.
+//
+// All renderers of this module use IsNull before
+// formatting a value, so that a null value is rendered
+// as FormatConfig.Nil or the null representation
+// of the output format independently of the value type.
+func IsNull(val reflect.Value) bool {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() || nullable.ReflectIsNull(val) {
+			return true
+		}
+		val = val.Elem()
+	}
+	return nullable.ReflectIsNull(val)
+}
+
+var (
+	nullableType = reflect.TypeOf((*nullable.Nullable)(nil)).Elem()
+	zeroableType = reflect.TypeOf((*nullable.Zeroable)(nil)).Elem()
+)
+
+// TypeCanBeNull returns false if IsNull
+// returns false for all values of type t,
+// so that the check can be skipped for them.
+// Types with a kind that can be nil and types
+// implementing nullable.Nullable or nullable.Zeroable
+// with a value or pointer receiver can be null.
+func TypeCanBeNull(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Ptr, reflect.Interface, reflect.Map, reflect.Slice, reflect.Chan, reflect.Func, reflect.UnsafePointer:
+		return true
+	}
+	ptr := reflect.PointerTo(t)
+	return t.Implements(nullableType) || t.Implements(zeroableType) ||
+		ptr.Implements(nullableType) || ptr.Implements(zeroableType)
+}
+
+// IsZeroNumber returns true if val or the value it points to
+// is of an integer or float kind like int, float64,
+// or money.Amount and has the value zero.
+// Used for the BlankZero options of the renderers.
+func IsZeroNumber(val reflect.Value) bool {
+	for val.Kind() == reflect.Ptr || val.Kind() == reflect.Interface {
+		if val.IsNil() {
+			return false
+		}
+		val = val.Elem()
+	}
+	switch val.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return val.Int() == 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return val.Uint() == 0
+	case reflect.Float32, reflect.Float64:
+		return val.Float() == 0
+	}
+	return false
+}
@@ -0,0 +1,71 @@
+package structtable
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-types/date"
+	"github.com/domonda/go-types/nullable"
+)
+
+func TestIsNull(t *testing.T) {
+	var (
+		nilPtr    *int
+		nilPtrPtr = &nilPtr
+		str       = "x"
+		nullTime  nullable.Time
+	)
+	tests := []struct {
+		name string
+		val  reflect.Value
+		want bool
+	}{
+		{name: "invalid", val: reflect.Value{}, want: true},
+		{name: "nil pointer", val: reflect.ValueOf(nilPtr), want: true},
+		{name: "pointer to nil pointer", val: reflect.ValueOf(nilPtrPtr), want: true},
+		{name: "nil slice", val: reflect.ValueOf([]byte(nil)), want: true},
+		{name: "zero time", val: reflect.ValueOf(time.Time{}), want: true},
+		{name: "null date", val: reflect.ValueOf(date.Null), want: true},
+		{name: "pointer to null date", val: reflect.ValueOf(&[]date.NullableDate{date.Null}[0]), want: true},
+		{name: "null time", val: reflect.ValueOf(nullTime), want: true},
+		{name: "zero int", val: reflect.ValueOf(0), want: false},
+		{name: "empty string", val: reflect.ValueOf(""), want: false},
+		{name: "string pointer", val: reflect.ValueOf(&str), want: false},
+		{name: "date", val: reflect.ValueOf(date.Date("2024-01-02")), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, IsNull(tt.val))
+			if tt.want && tt.val.IsValid() {
+				assert.True(t, TypeCanBeNull(tt.val.Type()), "TypeCanBeNull")
+			}
+		})
+	}
+}
+
+func TestTypeCanBeNull(t *testing.T) {
+	assert.False(t, TypeCanBeNull(reflect.TypeOf(0)))
+	assert.False(t, TypeCanBeNull(reflect.TypeOf("")))
+	assert.False(t, TypeCanBeNull(reflect.TypeOf(struct{ A int }{})))
+	assert.True(t, TypeCanBeNull(reflect.TypeOf(time.Time{})))
+	assert.True(t, TypeCanBeNull(reflect.TypeOf(nullable.Time{})))
+	assert.True(t, TypeCanBeNull(reflect.TypeOf(date.Date(""))))
+	assert.True(t, TypeCanBeNull(reflect.TypeOf((*any)(nil)).Elem()))
+}
+
+func TestIsZeroNumber(t *testing.T) {
+	zero := 0
+	assert.True(t, IsZeroNumber(reflect.ValueOf(0)))
+	assert.True(t, IsZeroNumber(reflect.ValueOf(uint8(0))))
+	assert.True(t, IsZeroNumber(reflect.ValueOf(0.0)))
+	assert.True(t, IsZeroNumber(reflect.ValueOf(&zero)))
+	assert.False(t, IsZeroNumber(reflect.ValueOf(-1)))
+	assert.False(t, IsZeroNumber(reflect.ValueOf(0.001)))
+	assert.False(t, IsZeroNumber(reflect.ValueOf((*int)(nil))), "nil pointer is null, not zero")
+	assert.False(t, IsZeroNumber(reflect.ValueOf(false)))
+	assert.False(t, IsZeroNumber(reflect.ValueOf("")))
+	assert.False(t, IsZeroNumber(reflect.Value{}))
+}
@@ -0,0 +1,68 @@
+package structtable
+
+import (
+	"reflect"
+
+	"github.com/domonda/go-errs"
+)
+
+// DefaultProgressInterval is a number of rows
+// between progress callbacks for RenderWithProgress.
+const DefaultProgressInterval = 1000
+
+// RenderWithProgress renders like Render and calls onProgress
+// with the number of rendered data rows and the total number of rows
+// every interval rows and once after the last row,
+// for example to update a progress indicator during long exports.
+// onProgress is not called after the last row
+// if rendering returned an error.
+// A nil onProgress renders like Render.
+func RenderWithProgress(renderer Renderer, structSlice any, renderTitleRow bool, columnMapper ColumnMapper, interval int, onProgress func(done, total int)) error {
+	if interval < 1 {
+		return errs.New("progress interval must be greater than zero")
+	}
+	if onProgress == nil {
+		return Render(renderer, structSlice, renderTitleRow, columnMapper)
+	}
+	rows, err := ReflectRows(structSlice)
+	if err != nil {
+		return err
+	}
+
+	progress := &progressRenderer{
+		Renderer:   renderer,
+		total:      rows.Len(),
+		interval:   interval,
+		onProgress: onProgress,
+	}
+	err = Render(progress, structSlice, renderTitleRow, columnMapper)
+	if err != nil {
+		return err
+	}
+	onProgress(progress.total, progress.total)
+
+	return nil
+}
+
+// progressRenderer wraps a Renderer and calls onProgress
+// every interval rendered rows before the last row.
+type progressRenderer struct {
+	Renderer
+
+	done       int
+	total      int
+	interval   int
+	onProgress func(done, total int)
+}
+
+func (p *progressRenderer) RenderRow(columnValues []reflect.Value) error {
+	err := p.Renderer.RenderRow(columnValues)
+	if err != nil {
+		return err
+	}
+	p.done++
+	if p.done%p.interval == 0 && p.done < p.total {
+		p.onProgress(p.done, p.total)
+	}
+	return nil
+}
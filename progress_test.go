@@ -0,0 +1,38 @@
+package structtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRenderWithProgress(t *testing.T) {
+	rows := []renderTestRow{{A: "a1"}, {A: "a2"}, {A: "a3"}, {A: "a4"}, {A: "a5"}}
+
+	var calls [][2]int
+	onProgress := func(done, total int) { calls = append(calls, [2]int{done, total}) }
+
+	r := new(recordingRenderer)
+	err := RenderWithProgress(r, rows, true, DefaultReflectColumnTitles, 2, onProgress)
+	assert.NoError(t, err)
+	assert.Len(t, r.rows, 5)
+	assert.Equal(t, [][2]int{{2, 5}, {4, 5}, {5, 5}}, calls)
+
+	calls = nil
+	err = RenderWithProgress(new(recordingRenderer), rows[:4], false, DefaultReflectColumnTitles, 2, onProgress)
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]int{{2, 4}, {4, 4}}, calls, "completion reported once")
+
+	calls = nil
+	err = RenderWithProgress(new(recordingRenderer), []renderTestRow{}, false, DefaultReflectColumnTitles, DefaultProgressInterval, onProgress)
+	assert.NoError(t, err)
+	assert.Equal(t, [][2]int{{0, 0}}, calls, "empty table")
+
+	r = new(recordingRenderer)
+	err = RenderWithProgress(r, rows, false, DefaultReflectColumnTitles, 2, nil)
+	assert.NoError(t, err, "nil onProgress")
+	assert.Len(t, r.rows, 5)
+
+	err = RenderWithProgress(r, rows, false, DefaultReflectColumnTitles, 0, onProgress)
+	assert.Error(t, err, "zero interval")
+}
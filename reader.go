@@ -9,10 +9,47 @@ import (
 type Reader interface {
 	NumRows() int
 	ReadRowStrings(index int) ([]string, error)
+	// ReadAllStrings returns the strings of all rows.
+	// ReadAllRowStrings can be used as default implementation.
+	ReadAllStrings() ([][]string, error)
 	ReadRow(index int, destStruct reflect.Value) error
 }
 
+// ReadAllRowStrings returns the strings of all rows of reader
+// by calling ReadRowStrings for every row.
+func ReadAllRowStrings(reader Reader) ([][]string, error) {
+	rows := make([][]string, reader.NumRows())
+	for i := range rows {
+		row, err := reader.ReadRowStrings(i)
+		if err != nil {
+			return nil, err
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// HeaderRowDetector can be implemented by a Reader
+// to detect the number of header rows of a table.
+type HeaderRowDetector interface {
+	// DetectNumHeaderRows returns the detected number of header rows.
+	// If ok is false then header detection is not enabled
+	// and the result must not be used.
+	DetectNumHeaderRows() (numHeaderRows int, ok bool)
+}
+
+// Read reads the rows of reader into the struct slice
+// pointed to by structSlicePtr and returns the first
+// numHeaderRows rows as headerRows.
+// If reader implements HeaderRowDetector and header detection
+// is enabled, then the detected number of header rows
+// is used instead of numHeaderRows.
 func Read(reader Reader, structSlicePtr interface{}, numHeaderRows int) (headerRows [][]string, err error) {
+	if detector, ok := reader.(HeaderRowDetector); ok {
+		if detected, ok := detector.DetectNumHeaderRows(); ok {
+			numHeaderRows = detected
+		}
+	}
 	if numHeaderRows < 0 {
 		return nil, errs.New("numHeaderRows can't be negative")
 	}
@@ -68,3 +105,48 @@ func Read(reader Reader, structSlicePtr interface{}, numHeaderRows int) (headerR
 	destVal.Elem().Set(sliceVal)
 	return headerRows, nil
 }
+
+// ReadFunc reads the rows of reader after the first numHeaderRows
+// one at a time into a reused value of type T and calls fn with it,
+// so that huge tables can be processed without holding
+// all rows in memory.
+// T must be a struct or a pointer to a struct.
+// The value is reset to its zero value before reading every row
+// and must not be retained by fn after returning,
+// copy it if needed.
+// Reading stops at the first error returned by fn or the reader.
+// Header detection works like with Read.
+func ReadFunc[T any](reader Reader, numHeaderRows int, fn func(row T) error) error {
+	if detector, ok := reader.(HeaderRowDetector); ok {
+		if detected, ok := detector.DetectNumHeaderRows(); ok {
+			numHeaderRows = detected
+		}
+	}
+	if numHeaderRows < 0 {
+		return errs.New("numHeaderRows can't be negative")
+	}
+	var row T
+	rowVal := reflect.ValueOf(&row).Elem()
+	destStruct := rowVal
+	if rowVal.Kind() == reflect.Ptr {
+		rowVal.Set(reflect.New(rowVal.Type().Elem()))
+		destStruct = rowVal.Elem()
+	}
+	if destStruct.Kind() != reflect.Struct {
+		return errs.Errorf("ReadFunc type must be a struct or pointer to a struct, but is %s", rowVal.Type())
+	}
+	zero := reflect.Zero(destStruct.Type())
+
+	for i := numHeaderRows; i < reader.NumRows(); i++ {
+		destStruct.Set(zero)
+		err := reader.ReadRow(i, destStruct)
+		if err != nil {
+			return err
+		}
+		err = fn(row)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package structtable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-errs"
+)
+
+// textTestReader completes TextReader to a Reader
+type textTestReader struct {
+	*TextReader
+}
+
+func (r textTestReader) ReadRowStrings(index int) ([]string, error) { return r.rows[index], nil }
+func (r textTestReader) ReadAllStrings() ([][]string, error)        { return r.rows, nil }
+
+func TestReadFunc(t *testing.T) {
+	type row struct {
+		Name  string
+		Count int
+	}
+	rows := [][]string{
+		{"Name", "Count"},
+		{"A", "1"},
+		{"B", "2"},
+		{"C", "3"},
+	}
+	reader := textTestReader{NewTextReader(rows, map[int]string{0: "Name", 1: "Count"}, "")}
+
+	var read []row
+	err := ReadFunc(reader, 1, func(r row) error {
+		read = append(read, r)
+		return nil
+	})
+	assert.NoError(t, err, "ReadFunc")
+	assert.Equal(t, []row{{"A", 1}, {"B", 2}, {"C", 3}}, read)
+
+	var names []string
+	errStop := errs.New("stop")
+	err = ReadFunc(reader, 1, func(r *row) error {
+		names = append(names, r.Name)
+		if r.Name == "B" {
+			return errStop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, []string{"A", "B"}, names, "stops at error")
+
+	err = ReadFunc(reader, 1, func(string) error { return nil })
+	assert.Error(t, err, "no struct type")
+}
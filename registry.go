@@ -0,0 +1,67 @@
+package structtable
+
+import (
+	"reflect"
+	"sync"
+)
+
+var (
+	columnMappers    = make(map[reflect.Type]ColumnMapper)
+	columnMappersMtx sync.RWMutex
+)
+
+// RegisterColumnMapper registers mapper as ColumnMapper
+// for structType used by ColumnMapperFor and RegisteredColumnMapper.
+// A pointer type registers the mapper for the struct type it points to.
+// A nil mapper removes the registration.
+// Safe for concurrent use.
+func RegisterColumnMapper(structType reflect.Type, mapper ColumnMapper) {
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	columnMappersMtx.Lock()
+	defer columnMappersMtx.Unlock()
+
+	if mapper == nil {
+		delete(columnMappers, structType)
+	} else {
+		columnMappers[structType] = mapper
+	}
+}
+
+// ColumnMapperFor returns the ColumnMapper registered for structType
+// with RegisterColumnMapper or DefaultReflectColumnTitles
+// if no ColumnMapper was registered.
+// Safe for concurrent use.
+func ColumnMapperFor(structType reflect.Type) ColumnMapper {
+	if structType.Kind() == reflect.Ptr {
+		structType = structType.Elem()
+	}
+	columnMappersMtx.RLock()
+	defer columnMappersMtx.RUnlock()
+
+	if mapper, ok := columnMappers[structType]; ok {
+		return mapper
+	}
+	return DefaultReflectColumnTitles
+}
+
+// RegisteredColumnMapper implements ColumnMapper by using
+// the ColumnMapper returned by ColumnMapperFor for the struct type.
+var RegisteredColumnMapper ColumnMapper = ColumnMapperFunc(func(structType reflect.Type) (titles []string, rowReflector RowReflector) {
+	return ColumnMapperFor(structType).ColumnTitlesAndRowReflector(structType)
+})
+
+// RenderRegistered renders like Render using
+// the ColumnMapper registered for the element type
+// of structSlice, see RegisteredColumnMapper.
+func RenderRegistered(renderer Renderer, structSlice any, renderTitleRow bool) error {
+	return Render(renderer, structSlice, renderTitleRow, RegisteredColumnMapper)
+}
+
+// RenderRegisteredBytes renders like RenderBytes using
+// the ColumnMapper registered for the element type
+// of structSlice, see RegisteredColumnMapper.
+func RenderRegisteredBytes(renderer Renderer, structSlice any, renderTitleRow bool) ([]byte, error) {
+	return RenderBytes(renderer, structSlice, renderTitleRow, RegisteredColumnMapper)
+}
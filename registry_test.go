@@ -0,0 +1,34 @@
+package structtable
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterColumnMapper(t *testing.T) {
+	type registeredRow struct {
+		A string
+		B string
+	}
+	rows := []*registeredRow{{A: "a", B: "b"}}
+	structType := reflect.TypeFor[registeredRow]()
+
+	r := new(recordingRenderer)
+	err := RenderRegistered(r, rows, true)
+	assert.NoError(t, err, "RenderRegistered")
+	assert.Equal(t, []string{"A", "B"}, r.header, "DefaultReflectColumnTitles")
+
+	RegisterColumnMapper(reflect.TypeFor[*registeredRow](), ReorderColumns(DefaultReflectColumnTitles, "B"))
+	t.Cleanup(func() { RegisterColumnMapper(structType, nil) })
+
+	r = new(recordingRenderer)
+	err = RenderRegistered(r, rows, true)
+	assert.NoError(t, err, "RenderRegistered")
+	assert.Equal(t, []string{"B", "A"}, r.header, "registered mapper")
+	assert.Equal(t, [][]string{{"b", "a"}}, r.rows)
+
+	RegisterColumnMapper(structType, nil)
+	assert.Equal(t, DefaultReflectColumnTitles, ColumnMapperFor(structType), "removed registration")
+}
@@ -2,16 +2,32 @@ package structtable
 
 import (
 	"io"
+	"iter"
 	"reflect"
+	"slices"
 
 	fs "github.com/ungerik/go-fs"
 
 	"github.com/domonda/go-errs"
+	"github.com/domonda/go-types/strfmt"
 )
 
+// Renderer renders rows of a table into an internal buffer
+// until the result is requested.
+//
+// Renderer implementations hold mutable state and are not safe
+// for concurrent use. Rendering into a renderer that already
+// holds a result appends to it or returns ErrFinished
+// if the renderer implements Finisher, so use a new renderer per table
+// or the Reset method that most implementations provide
+// to reuse a renderer sequentially.
 type Renderer interface {
 	RenderHeaderRow(columnTitles []string) error
 	RenderRow(columnValues []reflect.Value) error
+	// RenderFooterRow renders a footer row like totals after all rows.
+	// Invalid reflect.Value elements are rendered as empty cells.
+	// Renderers for formats without footer support implement it as no-op.
+	RenderFooterRow(columnValues []reflect.Value) error
 
 	Result() ([]byte, error)
 	WriteResultTo(w io.Writer) error
@@ -21,14 +37,108 @@ type Renderer interface {
 	MIMEType() string
 }
 
-func Render(renderer Renderer, structSlice interface{}, renderTitleRow bool, columnMapper ColumnMapper) error {
+// ErrFinished is returned when rendering into
+// a renderer after its Finish method was called.
+const ErrFinished errs.Sentinel = "renderer already finished"
+
+// Finisher can be implemented by a Renderer
+// to finalize the rendered table explicitly.
+//
+// Finish finalizes the table exactly once,
+// for example by writing the end of the table,
+// further calls are no-ops.
+// Result and WriteResultTo call Finish implicitly
+// and can be called multiple times returning the same result.
+// Renderers that write the end of a table in Finish
+// return ErrFinished for rendering after Finish
+// until the renderer is reset.
+type Finisher interface {
+	Finish() error
+}
+
+// ReflectRows returns the rows to render of structSlice
+// as reflect.Value of kind slice.
+// A pointer to a slice is dereferenced and
+// a single struct or pointer to a struct
+// is returned as slice with one element.
+func ReflectRows(structSlice any) (reflect.Value, error) {
 	rows := reflect.ValueOf(structSlice)
-	if rows.Kind() != reflect.Slice {
-		return errs.Errorf("passed value is not a slice, but %T", structSlice)
+	for rows.Kind() == reflect.Ptr && !rows.IsNil() && rows.Elem().Kind() != reflect.Struct {
+		rows = rows.Elem()
+	}
+	if rows.Kind() == reflect.Ptr && !rows.IsNil() {
+		rows = rows.Elem()
+	}
+	switch rows.Kind() {
+	case reflect.Slice:
+		return rows, nil
+	case reflect.Struct:
+		single := reflect.MakeSlice(reflect.SliceOf(rows.Type()), 1, 1)
+		single.Index(0).Set(rows)
+		return single, nil
+	}
+	return reflect.Value{}, errs.Errorf("passed value is not a slice or struct, but %T", structSlice)
+}
+
+// Render renders the rows of structSlice with renderer.
+// See ReflectRows for the accepted types of structSlice.
+func Render(renderer Renderer, structSlice interface{}, renderTitleRow bool, columnMapper ColumnMapper) error {
+	rows, err := ReflectRows(structSlice)
+	if err != nil {
+		return err
+	}
+
+	columnTitles, rowReflector := columnMapper.ColumnTitlesAndRowReflector(rows.Type().Elem())
+
+	return renderRows(renderer, columnTitles, renderTitleRow, reflectRows(rows, 0, rows.Len(), rowReflector), nil)
+}
+
+// RenderStrict renders like Render but returns an error
+// if the number of reflected values of a row differs
+// from the number of column titles of columnMapper,
+// for example because ColumnTitles were passed
+// with the wrong number of titles.
+// A nil titles slice, like from NoColumnTitles, is not checked.
+// Rows before the mismatching row are rendered.
+func RenderStrict(renderer Renderer, structSlice any, renderTitleRow bool, columnMapper ColumnMapper) error {
+	rows, err := ReflectRows(structSlice)
+	if err != nil {
+		return err
 	}
 
 	columnTitles, rowReflector := columnMapper.ColumnTitlesAndRowReflector(rows.Type().Elem())
 
+	checkRow := func(i int, columnValues []reflect.Value) ([]reflect.Value, error) {
+		if columnTitles != nil && len(columnValues) != len(columnTitles) {
+			return nil, errs.Errorf("row %d of %s has %d values for %d column titles %q", i, rows.Type().Elem(), len(columnValues), len(columnTitles), columnTitles)
+		}
+		return columnValues, nil
+	}
+	return renderRows(renderer, columnTitles, renderTitleRow, reflectRows(rows, 0, rows.Len(), rowReflector), checkRow)
+}
+
+// rowTransform returns the column values to render
+// for the reflected columnValues of the row with index i
+// or an error to stop rendering.
+type rowTransform func(i int, columnValues []reflect.Value) ([]reflect.Value, error)
+
+// reflectRows returns the column values of the rows
+// with the indices from start to end reflected by rowReflector.
+func reflectRows(rows reflect.Value, start, end int, rowReflector RowReflector) iter.Seq2[int, []reflect.Value] {
+	return func(yield func(int, []reflect.Value) bool) {
+		for i := start; i < end; i++ {
+			if !yield(i, rowReflector.ReflectRow(rows.Index(i))) {
+				return
+			}
+		}
+	}
+}
+
+// renderRows renders a header row with columnTitles if renderTitleRow is true
+// followed by the column values of rows passed through transform
+// if it is not nil.
+// It implements the render loop shared by all Render functions.
+func renderRows(renderer Renderer, columnTitles []string, renderTitleRow bool, rows iter.Seq2[int, []reflect.Value], transform rowTransform) error {
 	if renderTitleRow {
 		err := renderer.RenderHeaderRow(columnTitles)
 		if err != nil {
@@ -36,8 +146,15 @@ func Render(renderer Renderer, structSlice interface{}, renderTitleRow bool, col
 		}
 	}
 
-	for i := 0; i < rows.Len(); i++ {
-		err := renderer.RenderRow(rowReflector.ReflectRow(rows.Index(i)))
+	for i, columnValues := range rows {
+		if transform != nil {
+			var err error
+			columnValues, err = transform(i, columnValues)
+			if err != nil {
+				return err
+			}
+		}
+		err := renderer.RenderRow(columnValues)
 		if err != nil {
 			return err
 		}
@@ -46,6 +163,117 @@ func Render(renderer Renderer, structSlice interface{}, renderTitleRow bool, col
 	return nil
 }
 
+// FormatConfigurable is implemented by renderers
+// that format values using a strfmt.FormatConfig.
+type FormatConfigurable interface {
+	FormatConfig() *strfmt.FormatConfig
+	SetFormatConfig(config *strfmt.FormatConfig)
+}
+
+// RenderWithConfig renders like Render but formats the values
+// with the passed config instead of the FormatConfig of the renderer
+// which has to implement FormatConfigurable.
+// The original FormatConfig of the renderer is restored after rendering.
+//
+// Neither the passed nor the original FormatConfig are modified,
+// so configs can be shared between renderers used by different goroutines.
+// A renderer itself must never be used concurrently.
+func RenderWithConfig(renderer Renderer, structSlice any, renderTitleRow bool, columnMapper ColumnMapper, config *strfmt.FormatConfig) error {
+	configurable, ok := renderer.(FormatConfigurable)
+	if !ok {
+		return errs.Errorf("renderer %T does not implement FormatConfigurable", renderer)
+	}
+	if config == nil {
+		return errs.New("nil FormatConfig")
+	}
+	original := configurable.FormatConfig()
+	configurable.SetFormatConfig(config)
+	defer configurable.SetFormatConfig(original)
+
+	return Render(renderer, structSlice, renderTitleRow, columnMapper)
+}
+
+// RenderWithFooter renders like Render and then renders
+// the column values returned by the footer function
+// called with structSlice as footer row.
+// Use it for example for a totals row under money columns.
+func RenderWithFooter(renderer Renderer, structSlice any, renderTitleRow bool, columnMapper ColumnMapper, footer func(structSlice any) []reflect.Value) error {
+	err := Render(renderer, structSlice, renderTitleRow, columnMapper)
+	if err != nil {
+		return err
+	}
+	if footer == nil {
+		return nil
+	}
+	return renderer.RenderFooterRow(footer(structSlice))
+}
+
+// RenderTyped renders rows like Render but type safe
+// with the element type derived from T at compile time
+// instead of checking the kind of a structSlice at runtime.
+// T can also be a pointer to a struct type.
+func RenderTyped[T any](renderer Renderer, rows []T, renderTitleRow bool, columnMapper ColumnMapper) error {
+	return RenderSeq(renderer, slices.Values(rows), renderTitleRow, columnMapper)
+}
+
+// RenderSeq renders the structs yielded by seq without
+// requiring them to be materialized as slice.
+// The column titles are reflected once from the type T,
+// so the title row is also rendered for an empty seq
+// if renderTitleRow is true.
+func RenderSeq[T any](renderer Renderer, seq iter.Seq[T], renderTitleRow bool, columnMapper ColumnMapper) error {
+	columnTitles, rowReflector := columnMapper.ColumnTitlesAndRowReflector(reflect.TypeFor[T]())
+
+	rows := func(yield func(int, []reflect.Value) bool) {
+		i := 0
+		for row := range seq {
+			if !yield(i, rowReflector.ReflectRow(reflect.ValueOf(&row).Elem())) {
+				return
+			}
+			i++
+		}
+	}
+	return renderRows(renderer, columnTitles, renderTitleRow, rows, nil)
+}
+
+// RenderMaps renders maps as rows using columns as column titles
+// and as keys for the values of the columns in that order.
+// Values of keys missing in a map are rendered as null.
+func RenderMaps(renderer Renderer, maps []map[string]any, columns []string, renderTitleRow bool) error {
+	rows := func(yield func(int, []reflect.Value) bool) {
+		for i, m := range maps {
+			columnValues := make([]reflect.Value, len(columns))
+			for col, column := range columns {
+				// reflect.ValueOf returns the zero reflect.Value
+				// for missing keys and nil values
+				columnValues[col] = reflect.ValueOf(m[column])
+			}
+			if !yield(i, columnValues) {
+				return
+			}
+		}
+	}
+	return renderRows(renderer, columns, renderTitleRow, rows, nil)
+}
+
+// RenderRows renders rows of positional values.
+// If titles is not nil, then a header row with titles is rendered
+// and rows with fewer values than titles are filled up with null values.
+func RenderRows(renderer Renderer, rows [][]any, titles []string) error {
+	rowValues := func(yield func(int, []reflect.Value) bool) {
+		for i, row := range rows {
+			columnValues := make([]reflect.Value, max(len(row), len(titles)))
+			for col, val := range row {
+				columnValues[col] = reflect.ValueOf(val)
+			}
+			if !yield(i, columnValues) {
+				return
+			}
+		}
+	}
+	return renderRows(renderer, titles, titles != nil, rowValues, nil)
+}
+
 func RenderTo(writer io.Writer, renderer Renderer, structSlice interface{}, renderTitleRow bool, columnMapper ColumnMapper) error {
 	err := Render(renderer, structSlice, renderTitleRow, columnMapper)
 	if err != nil {
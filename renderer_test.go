@@ -0,0 +1,158 @@
+package structtable
+
+import (
+	"io"
+	"reflect"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	fs "github.com/ungerik/go-fs"
+)
+
+// recordingRenderer implements Renderer by recording
+// the header and rows as strings for testing.
+type recordingRenderer struct {
+	header []string
+	rows   [][]string
+}
+
+func (r *recordingRenderer) RenderHeaderRow(columnTitles []string) error {
+	r.header = columnTitles
+	return nil
+}
+
+func (r *recordingRenderer) RenderRow(columnValues []reflect.Value) error {
+	row := make([]string, len(columnValues))
+	for i, val := range columnValues {
+		row[i] = val.String()
+	}
+	r.rows = append(r.rows, row)
+	return nil
+}
+
+func (r *recordingRenderer) RenderFooterRow(columnValues []reflect.Value) error {
+	return r.RenderRow(columnValues)
+}
+
+func (r *recordingRenderer) Result() ([]byte, error)                                    { return nil, nil }
+func (r *recordingRenderer) WriteResultTo(w io.Writer) error                            { return nil }
+func (r *recordingRenderer) WriteResultFile(file fs.File, perm ...fs.Permissions) error { return nil }
+func (r *recordingRenderer) MIMEType() string                                           { return "" }
+
+type renderTestRow struct {
+	A string
+	B string `col:"Bee"`
+}
+
+func TestRenderPointerAndSingleStruct(t *testing.T) {
+	rows := []renderTestRow{{A: "a1", B: "b1"}, {A: "a2", B: "b2"}}
+
+	r := new(recordingRenderer)
+	err := Render(r, &rows, true, DefaultReflectColumnTitles)
+	assert.NoError(t, err, "pointer to slice")
+	assert.Equal(t, []string{"A", "Bee"}, r.header)
+	assert.Equal(t, [][]string{{"a1", "b1"}, {"a2", "b2"}}, r.rows)
+
+	r = new(recordingRenderer)
+	err = Render(r, rows[0], true, DefaultReflectColumnTitles)
+	assert.NoError(t, err, "single struct")
+	assert.Equal(t, []string{"A", "Bee"}, r.header)
+	assert.Equal(t, [][]string{{"a1", "b1"}}, r.rows)
+
+	r = new(recordingRenderer)
+	err = Render(r, &rows[1], false, DefaultReflectColumnTitles)
+	assert.NoError(t, err, "pointer to single struct")
+	assert.Equal(t, [][]string{{"a2", "b2"}}, r.rows)
+
+	err = Render(r, "no table", false, DefaultReflectColumnTitles)
+	assert.Error(t, err, "string")
+	err = Render(r, (*[]renderTestRow)(nil), false, DefaultReflectColumnTitles)
+	assert.Error(t, err, "nil pointer")
+}
+
+func TestRenderStrict(t *testing.T) {
+	rows := []renderTestRow{{A: "a1", B: "b1"}, {A: "a2", B: "b2"}}
+
+	r := new(recordingRenderer)
+	err := RenderStrict(r, rows, true, ColumnTitles{"A", "B"})
+	assert.NoError(t, err)
+	assert.Len(t, r.rows, 2)
+
+	r = new(recordingRenderer)
+	err = RenderStrict(r, rows, true, ColumnTitles{"A", "B", "C"})
+	assert.ErrorContains(t, err, "2 values for 3 column titles")
+	assert.Empty(t, r.rows)
+
+	err = RenderStrict(new(recordingRenderer), rows, false, NoColumnTitles())
+	assert.NoError(t, err, "nil titles not checked")
+}
+
+func TestRenderTyped(t *testing.T) {
+	rows := []renderTestRow{{A: "a1", B: "b1"}, {A: "a2", B: "b2"}}
+
+	r := new(recordingRenderer)
+	err := RenderTyped(r, rows, true, DefaultReflectColumnTitles)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A", "Bee"}, r.header)
+	assert.Equal(t, [][]string{{"a1", "b1"}, {"a2", "b2"}}, r.rows)
+
+	r = new(recordingRenderer)
+	err = RenderTyped(r, []*renderTestRow{&rows[1]}, true, DefaultReflectColumnTitles)
+	assert.NoError(t, err, "pointer elements")
+	assert.Equal(t, []string{"A", "Bee"}, r.header)
+	assert.Equal(t, [][]string{{"a2", "b2"}}, r.rows)
+
+	// Empty slice still renders the header row
+	r = new(recordingRenderer)
+	err = RenderTyped(r, []renderTestRow(nil), true, DefaultReflectColumnTitles)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A", "Bee"}, r.header)
+	assert.Empty(t, r.rows)
+}
+
+func TestRenderSeq(t *testing.T) {
+	rows := []renderTestRow{{A: "a1", B: "b1"}, {A: "a2", B: "b2"}}
+
+	r := new(recordingRenderer)
+	err := RenderSeq(r, slices.Values(rows), true, DefaultReflectColumnTitles)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A", "Bee"}, r.header)
+	assert.Equal(t, [][]string{{"a1", "b1"}, {"a2", "b2"}}, r.rows)
+
+	// Empty sequence still renders the header row
+	r = new(recordingRenderer)
+	err = RenderSeq(r, slices.Values([]renderTestRow(nil)), true, DefaultReflectColumnTitles)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A", "Bee"}, r.header)
+	assert.Empty(t, r.rows)
+}
+
+func TestRenderMaps(t *testing.T) {
+	maps := []map[string]any{
+		{"A": "a1", "B": "b1", "C": "ignored"},
+		{"B": "b2"},
+	}
+
+	r := new(recordingRenderer)
+	err := RenderMaps(r, maps, []string{"B", "A"}, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"B", "A"}, r.header)
+	assert.Equal(t, [][]string{{"b1", "a1"}, {"b2", "<invalid Value>"}}, r.rows)
+}
+
+func TestRenderRows(t *testing.T) {
+	rows := [][]any{{"a1", "b1"}, {"a2"}}
+
+	r := new(recordingRenderer)
+	err := RenderRows(r, rows, []string{"A", "B"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"A", "B"}, r.header)
+	assert.Equal(t, [][]string{{"a1", "b1"}, {"a2", "<invalid Value>"}}, r.rows)
+
+	r = new(recordingRenderer)
+	err = RenderRows(r, rows, nil)
+	assert.NoError(t, err)
+	assert.Nil(t, r.header)
+	assert.Equal(t, [][]string{{"a1", "b1"}, {"a2"}}, r.rows)
+}
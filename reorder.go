@@ -0,0 +1,47 @@
+package structtable
+
+import "reflect"
+
+// ReorderColumns returns a ColumnMapper that uses columnMapper
+// and reorders its columns by their titles in the order of the passed titles.
+// Columns with titles not listed in order follow
+// the listed columns in their original order.
+// Titles in order without a column are ignored.
+func ReorderColumns(columnMapper ColumnMapper, order ...string) ColumnMapper {
+	return ColumnMapperFunc(func(structType reflect.Type) ([]string, RowReflector) {
+		titles, rowReflector := columnMapper.ColumnTitlesAndRowReflector(structType)
+
+		// indices[newCol] is the column index from columnMapper
+		indices := make([]int, 0, len(titles))
+		used := make([]bool, len(titles))
+		for _, title := range order {
+			for col, t := range titles {
+				if t == title && !used[col] {
+					indices = append(indices, col)
+					used[col] = true
+					break
+				}
+			}
+		}
+		for col := range titles {
+			if !used[col] {
+				indices = append(indices, col)
+			}
+		}
+
+		reordered := make([]string, len(indices))
+		for i, col := range indices {
+			reordered[i] = titles[col]
+		}
+		return reordered, RowReflectorFunc(func(structValue reflect.Value) []reflect.Value {
+			columnValues := rowReflector.ReflectRow(structValue)
+			reorderedValues := make([]reflect.Value, len(indices))
+			for i, col := range indices {
+				if col < len(columnValues) {
+					reorderedValues[i] = columnValues[col]
+				}
+			}
+			return reorderedValues
+		})
+	})
+}
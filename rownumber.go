@@ -0,0 +1,31 @@
+package structtable
+
+import "reflect"
+
+// WithRowNumberColumn returns a ColumnMapper that uses columnMapper
+// and prepends a column with the passed title
+// containing the int number of every row,
+// starting with startAt for the first row.
+// If columnMapper returns nil titles, then no title is prepended.
+//
+// The row number is counted by the RowReflector returned
+// for every call of ColumnTitlesAndRowReflector, so every rendered table
+// starts again with startAt, but the RowReflector must not be
+// used concurrently or for multiple tables.
+func WithRowNumberColumn(columnMapper ColumnMapper, title string, startAt int) ColumnMapper {
+	return ColumnMapperFunc(func(structType reflect.Type) ([]string, RowReflector) {
+		titles, rowReflector := columnMapper.ColumnTitlesAndRowReflector(structType)
+		if titles != nil {
+			titles = append([]string{title}, titles...)
+		}
+		rowNumber := startAt
+		return titles, RowReflectorFunc(func(structValue reflect.Value) []reflect.Value {
+			columnValues := rowReflector.ReflectRow(structValue)
+			numberedValues := make([]reflect.Value, 1+len(columnValues))
+			numberedValues[0] = reflect.ValueOf(rowNumber)
+			copy(numberedValues[1:], columnValues)
+			rowNumber++
+			return numberedValues
+		})
+	})
+}
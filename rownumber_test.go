@@ -0,0 +1,33 @@
+package structtable
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-types/strfmt"
+)
+
+func TestWithRowNumberColumn(t *testing.T) {
+	rows := []renderTestRow{{A: "a1", B: "b1"}, {A: "a2", B: "b2"}, {A: "a3"}}
+	columnMapper := WithRowNumberColumn(DefaultReflectColumnTitles, "#", 1)
+
+	txt := newJoinRenderer(strfmt.NewFormatConfig())
+	result, err := RenderBytes(txt, rows, true, columnMapper)
+	assert.NoError(t, err)
+	assert.Equal(t, "#,A,Bee\n1,a1,b1\n2,a2,b2\n3,a3,\n", string(result))
+
+	txt.Reset()
+	result, err = RenderBytes(txt, rows[:1], false, columnMapper)
+	assert.NoError(t, err)
+	assert.Equal(t, "1,a1,b1\n", string(result), "starts at startAt for every table")
+
+	txt.Reset()
+	columnMapper = WithRowNumberColumn(NoColumnTitles(), "#", 0)
+	titles, _ := columnMapper.ColumnTitlesAndRowReflector(reflect.TypeOf(renderTestRow{}))
+	assert.Nil(t, titles, "nil titles stay nil")
+	result, err = RenderBytes(txt, rows[:2], false, columnMapper)
+	assert.NoError(t, err)
+	assert.Equal(t, "0,a1,b1\n1,a2,b2\n", string(result))
+}
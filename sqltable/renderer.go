@@ -0,0 +1,260 @@
+package sqltable
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	fs "github.com/ungerik/go-fs"
+
+	"github.com/domonda/go-structtable"
+	"github.com/domonda/go-types/nullable"
+)
+
+// Renderer implements structtable.Renderer by rendering
+// rows as SQL INSERT statements for a table.
+// Renderer is not safe for concurrent use,
+// call Reset to reuse it for another table.
+type Renderer struct {
+	tableName string
+	columns   []string
+	rows      []string
+	buf       bytes.Buffer
+
+	// BatchSize is the maximum number of rows
+	// per multi-row INSERT statement.
+	// A value of zero or less renders all rows
+	// with a single INSERT statement.
+	BatchSize int
+}
+
+// NewRenderer returns a Renderer that inserts into tableName.
+// The table name may be qualified by a schema like "public.invoice",
+// every dot separated part will be sanitized to an identifier.
+func NewRenderer(tableName string) *Renderer {
+	return &Renderer{tableName: sanitizeTableName(tableName)}
+}
+
+// WithBatchSize sets the BatchSize and returns the Renderer
+func (sql *Renderer) WithBatchSize(batchSize int) *Renderer {
+	sql.BatchSize = batchSize
+	return sql
+}
+
+// RenderHeaderRow captures the column titles sanitized
+// to SQL identifiers as column names of the INSERT statements.
+// If no header row is rendered, then the INSERT statements
+// will be rendered without column names.
+func (sql *Renderer) RenderHeaderRow(columnTitles []string) error {
+	sql.columns = make([]string, len(columnTitles))
+	for i, title := range columnTitles {
+		sql.columns[i] = SanitizeIdentifier(title)
+	}
+	return nil
+}
+
+func (sql *Renderer) RenderRow(columnValues []reflect.Value) error {
+	var b strings.Builder
+	b.WriteByte('(')
+	for i, val := range columnValues {
+		if i > 0 {
+			b.WriteString(", ")
+		}
+		b.WriteString(Literal(val))
+	}
+	b.WriteByte(')')
+	sql.rows = append(sql.rows, b.String())
+
+	if sql.BatchSize > 0 && len(sql.rows) >= sql.BatchSize {
+		return sql.flush()
+	}
+	return nil
+}
+
+// RenderFooterRow is a no-op because
+// footer rows are not supported by SQL.
+func (*Renderer) RenderFooterRow(columnValues []reflect.Value) error {
+	return nil
+}
+
+// Reset clears the rendered statements and column names
+// so that the Renderer can be reused.
+func (sql *Renderer) Reset() {
+	sql.columns = nil
+	sql.rows = nil
+	sql.buf.Reset()
+}
+
+// flush writes an INSERT statement for all pending rows
+func (sql *Renderer) flush() error {
+	if len(sql.rows) == 0 {
+		return nil
+	}
+	var err error
+	if len(sql.columns) > 0 {
+		_, err = fmt.Fprintf(&sql.buf, "INSERT INTO %s (%s) VALUES\n", sql.tableName, strings.Join(sql.columns, ", "))
+	} else {
+		_, err = fmt.Fprintf(&sql.buf, "INSERT INTO %s VALUES\n", sql.tableName)
+	}
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(&sql.buf, "\t%s;\n", strings.Join(sql.rows, ",\n\t"))
+	if err != nil {
+		return err
+	}
+	sql.rows = sql.rows[:0]
+	return nil
+}
+
+// Finish writes the INSERT statement for all pending rows.
+// Implements structtable.Finisher.
+func (sql *Renderer) Finish() error {
+	return sql.flush()
+}
+
+func (sql *Renderer) Result() ([]byte, error) {
+	err := sql.Finish()
+	if err != nil {
+		return nil, err
+	}
+	return sql.buf.Bytes(), nil
+}
+
+func (sql *Renderer) WriteResultTo(writer io.Writer) error {
+	err := sql.Finish()
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(sql.buf.Bytes())
+	return err
+}
+
+func (sql *Renderer) WriteResultFile(file fs.File, perm ...fs.Permissions) error {
+	writer, err := file.OpenWriter(perm...)
+	if err != nil {
+		return err
+	}
+	defer writer.Close()
+
+	return sql.WriteResultTo(writer)
+}
+
+func (*Renderer) MIMEType() string {
+	return "application/sql"
+}
+
+// Literal returns val formatted as SQL literal.
+// Null values are returned as NULL, numbers and booleans unquoted,
+// time.Time in ISO 8601 format and everything else
+// as quoted string with single quotes escaped by doubling them.
+func Literal(val reflect.Value) string {
+	if structtable.IsNull(val) {
+		return "NULL"
+	}
+
+	derefVal := val
+	for derefVal.Kind() == reflect.Ptr || derefVal.Kind() == reflect.Interface {
+		derefVal = derefVal.Elem()
+	}
+	if !derefVal.IsValid() {
+		return "NULL"
+	}
+
+	switch x := derefVal.Interface().(type) {
+	case time.Time:
+		return QuoteString(x.Format(time.RFC3339Nano))
+	case nullable.Time:
+		return QuoteString(x.Time.Format(time.RFC3339Nano))
+	case []byte:
+		return QuoteString(string(x))
+	}
+
+	switch derefVal.Kind() {
+	case reflect.Bool:
+		if derefVal.Bool() {
+			return "TRUE"
+		}
+		return "FALSE"
+
+	case reflect.String:
+		return QuoteString(derefVal.String())
+
+	case reflect.Float32, reflect.Float64:
+		f := derefVal.Float()
+		switch {
+		case math.IsNaN(f):
+			return "'NaN'"
+		case math.IsInf(f, 1):
+			return "'Infinity'"
+		case math.IsInf(f, -1):
+			return "'-Infinity'"
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64)
+
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(derefVal.Int(), 10)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(derefVal.Uint(), 10)
+	}
+
+	if s, ok := val.Interface().(fmt.Stringer); ok {
+		return QuoteString(s.String())
+	}
+	if val.CanAddr() {
+		if s, ok := val.Addr().Interface().(fmt.Stringer); ok {
+			return QuoteString(s.String())
+		}
+	}
+	if s, ok := derefVal.Interface().(fmt.Stringer); ok {
+		return QuoteString(s.String())
+	}
+
+	return QuoteString(fmt.Sprint(derefVal.Interface()))
+}
+
+// QuoteString returns str as single quoted SQL string literal
+// with single quotes within str escaped by doubling them.
+func QuoteString(str string) string {
+	return "'" + strings.ReplaceAll(str, "'", "''") + "'"
+}
+
+// SanitizeIdentifier returns name as valid unquoted SQL identifier
+// by replacing all characters except ASCII letters, digits and underscores
+// with underscores. An underscore will be prepended if the name
+// starts with a digit or is empty.
+func SanitizeIdentifier(name string) string {
+	var b strings.Builder
+	b.Grow(len(name) + 1)
+	for i, r := range strings.TrimSpace(name) {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r == '_':
+			b.WriteRune(r)
+		case r >= '0' && r <= '9':
+			if i == 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+		default:
+			b.WriteByte('_')
+		}
+	}
+	if b.Len() == 0 {
+		return "_"
+	}
+	return b.String()
+}
+
+func sanitizeTableName(name string) string {
+	parts := strings.Split(name, ".")
+	for i, part := range parts {
+		parts[i] = SanitizeIdentifier(part)
+	}
+	return strings.Join(parts, ".")
+}
@@ -0,0 +1,83 @@
+package sqltable
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-structtable"
+	"github.com/domonda/go-types/date"
+)
+
+type testRow struct {
+	ID      int
+	Name    string
+	Amount  float64
+	Paid    bool
+	Date    date.Date
+	Comment *string
+}
+
+func Test_RenderSQL(t *testing.T) {
+	comment := "It's paid"
+	rows := []testRow{
+		{ID: 1, Name: "O'Brien", Amount: 12.5, Paid: true, Date: "2012-12-12", Comment: &comment},
+		{ID: 2, Name: "Smith", Amount: -3},
+		{ID: 3, Name: "", Amount: 0, Date: "2020-01-31"},
+	}
+
+	renderer := NewRenderer("public.invoice payments").WithBatchSize(2)
+	err := structtable.Render(renderer, rows, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+
+	const expected = `INSERT INTO public.invoice_payments (ID, Name, Amount, Paid, Date, Comment) VALUES
+	(1, 'O''Brien', 12.5, TRUE, '2012-12-12', 'It''s paid'),
+	(2, 'Smith', -3, FALSE, NULL, NULL);
+INSERT INTO public.invoice_payments (ID, Name, Amount, Paid, Date, Comment) VALUES
+	(3, '', 0, FALSE, '2020-01-31', NULL);
+`
+	assert.Equal(t, expected, string(result))
+}
+
+func TestLiteralInterfaceValues(t *testing.T) {
+	type row struct {
+		ID    any
+		Price any
+		Name  any
+		Null  any
+	}
+	renderer := NewRenderer("t")
+	err := structtable.Render(renderer, []row{{ID: 5, Price: 1.5, Name: "x"}}, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Contains(t, string(result), "(5, 1.5, 'x', NULL)")
+
+	var amount any = 2
+	assert.Equal(t, "2", Literal(reflect.ValueOf(&amount)), "pointer to interface")
+}
+
+func TestSanitizeIdentifier(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "", want: "_"},
+		{name: "ID", want: "ID"},
+		{name: "Money Amount", want: "Money_Amount"},
+		{name: "[]byte string", want: "__byte_string"},
+		{name: "1st", want: "_1st"},
+		{name: " Ä-b ", want: "__b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SanitizeIdentifier(tt.name); got != tt.want {
+				t.Errorf("SanitizeIdentifier() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
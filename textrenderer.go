@@ -3,8 +3,13 @@ package structtable
 import (
 	"bytes"
 	"io"
+	"math"
 	"reflect"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/domonda/go-types/float"
 	"github.com/domonda/go-types/strfmt"
 	fs "github.com/ungerik/go-fs"
 )
@@ -20,17 +25,82 @@ type TextFormatRenderer interface {
 
 // TextRenderer implements Renderer by using a TextFormatRenderer
 // for a specific text based table format.
+// TextRenderer is not safe for concurrent use,
+// call Reset to reuse it for another table.
 type TextRenderer struct {
+	// ColumnFloatFormat overrides the Float format of the config
+	// for the columns with the map key as index.
+	ColumnFloatFormat map[int]float.FormatDef
+	// ColumnBool overrides the True and False strings of the config
+	// for the columns with the map key as index.
+	ColumnBool map[int][2]string
+	// Location is used to format time.Time and nullable.Time values,
+	// nil formats them in their own location.
+	Location *time.Location
+	// ComplexValueFormatter is used for values of types
+	// where IsComplexType returns true and that have
+	// no TypeFormatters entry in the config.
+	// If nil, such values are formatted by strfmt.FormatValue.
+	// NewTextRenderer sets it to FormatComplexValue.
+	ComplexValueFormatter ComplexValueFormatter
+	// SliceJoiner joins the formatted elements of slices and arrays
+	// that would be formatted by the ComplexValueFormatter
+	// if not empty, see FormatJoinedSlice.
+	// NewTextRenderer sets it to DefaultSliceJoiner.
+	SliceJoiner string
+	// IntThousandsSep groups the digits of integers
+	// without TypeFormatters entry in the config
+	// by thousands if not zero, like 1.000.000 for '.'.
+	// Zero by default to keep integers machine readable.
+	IntThousandsSep rune
+	// EnumFormatters renders integer values of the mapped types
+	// with the names of their lookup table.
+	// Values missing in a lookup table are formatted as numbers.
+	EnumFormatters EnumFormatters
+	// NaNString is used for NaN values of float kind types
+	// instead of the format of the config.
+	NaNString string
+	// InfString is used for positive and negative infinite values
+	// of float kind types instead of the format of the config.
+	InfString string
+	// BlankZero renders zero values of integer and float kind types
+	// like int, float64, or money.Amount as the Nil string of the config,
+	// see IsZeroNumber. Integers with a name in EnumFormatters
+	// or a TypeFormatters entry in the config are not blanked
+	// because they are not formatted as numbers.
+	BlankZero bool
+
 	format       TextFormatRenderer
 	config       *strfmt.FormatConfig
 	buf          bytes.Buffer
 	beginWritten bool
+	finished     bool
+
+	// columns caches the formatting decisions per column
+	// made for the first rendered row because all rows
+	// share the same column types.
+	// Changes of ColumnFloatFormat or ColumnBool
+	// after the first row need a Reset to take effect.
+	columns []textColumn
+}
+
+// textColumn caches how the values of a column are formatted
+type textColumn struct {
+	config *strfmt.FormatConfig
+	// valType is the type canBeNull was determined for
+	valType   reflect.Type
+	canBeNull bool
+	isComplex bool
+	isInt     bool
+	isFloat   bool
 }
 
 func NewTextRenderer(format TextFormatRenderer, config *strfmt.FormatConfig) *TextRenderer {
 	tw := &TextRenderer{
-		format: format,
-		config: config,
+		ComplexValueFormatter: FormatComplexValue,
+		SliceJoiner:           DefaultSliceJoiner,
+		format:                format,
+		config:                config,
 	}
 	return tw
 }
@@ -43,7 +113,38 @@ func NewTextRenderer(format TextFormatRenderer, config *strfmt.FormatConfig) *Te
 // 	}
 // }
 
+// FormatConfig returns the config used to format values.
+// Implements FormatConfigurable.
+func (txt *TextRenderer) FormatConfig() *strfmt.FormatConfig {
+	return txt.config
+}
+
+// SetFormatConfig sets the config used to format values.
+// Implements FormatConfigurable.
+func (txt *TextRenderer) SetFormatConfig(config *strfmt.FormatConfig) {
+	txt.config = config
+	txt.columns = nil
+}
+
+// SetTypeRegistry sets a copy of the current FormatConfig
+// with the Text formatters of registry as FormatConfig.
+func (txt *TextRenderer) SetTypeRegistry(registry *TypeRegistry) {
+	txt.SetFormatConfig(registry.FormatConfig(txt.config))
+}
+
+// Reset clears the rendered text so that
+// the TextRenderer can be reused for another table.
+func (txt *TextRenderer) Reset() {
+	txt.buf.Reset()
+	txt.beginWritten = false
+	txt.finished = false
+	txt.columns = nil
+}
+
 func (txt *TextRenderer) writeBeginIfMissing() error {
+	if txt.finished {
+		return ErrFinished
+	}
 	if txt.beginWritten {
 		return nil
 	}
@@ -68,15 +169,189 @@ func (txt *TextRenderer) RenderRow(columnValues []reflect.Value) error {
 	if err != nil {
 		return err
 	}
+	if len(txt.columns) < len(columnValues) {
+		txt.columns = make([]textColumn, len(columnValues))
+		for i := range txt.columns {
+			txt.columns[i].config = txt.columnConfig(i)
+		}
+	}
 	fields := make([]string, len(columnValues))
 	for i, val := range columnValues {
-		fields[i] = strfmt.FormatValue(val, txt.config)
+		column := &txt.columns[i]
+		if val.Kind() == reflect.Interface && !val.IsNil() {
+			// Use the dynamic type of interface values
+			val = val.Elem()
+		}
+		if val.IsValid() && val.Type() != column.valType {
+			column.valType = val.Type()
+			column.canBeNull = TypeCanBeNull(column.valType)
+			column.isComplex = txt.isComplexType(column.valType, column.config)
+			column.isInt = isIntType(column.valType) && !hasTypeFormatter(column.valType, column.config)
+			column.isFloat = isFloatType(column.valType)
+		}
+		if (!val.IsValid() || column.canBeNull) && IsNull(val) {
+			fields[i] = txt.config.Nil
+			continue
+		}
+		if column.isComplex && txt.SliceJoiner != "" {
+			if str, ok := FormatJoinedSlice(val, txt.SliceJoiner, column.config); ok {
+				fields[i] = str
+				continue
+			}
+		}
+		if column.isComplex && txt.ComplexValueFormatter != nil {
+			fields[i] = txt.ComplexValueFormatter(val)
+			continue
+		}
+		if column.isFloat {
+			if str, ok := txt.nonFiniteFloatString(val); ok {
+				fields[i] = str
+				continue
+			}
+		}
+		if column.isInt {
+			if name, ok := txt.EnumFormatters.Format(val); ok {
+				fields[i] = name
+				continue
+			}
+		}
+		if txt.BlankZero && (column.isInt || column.isFloat) && IsZeroNumber(val) {
+			fields[i] = txt.config.Nil
+			continue
+		}
+		if column.isInt && txt.IntThousandsSep != 0 {
+			fields[i] = formatGroupedInt(val, txt.IntThousandsSep)
+			continue
+		}
+		if txt.Location != nil {
+			val = TimeInLocation(val, txt.Location)
+		}
+		fields[i] = strfmt.FormatValue(val, column.config)
 	}
 	return txt.format.RenderRowText(&txt.buf, fields)
 }
 
-func (txt *TextRenderer) Result() ([]byte, error) {
+// isComplexType returns if values of type t
+// are formatted by the ComplexValueFormatter
+func (txt *TextRenderer) isComplexType(t reflect.Type, config *strfmt.FormatConfig) bool {
+	return IsComplexType(t) && !hasTypeFormatter(t, config)
+}
+
+// hasTypeFormatter returns if config has a TypeFormatters entry
+// for t or the type t points to
+func hasTypeFormatter(t reflect.Type, config *strfmt.FormatConfig) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	_, ok := config.TypeFormatters[t]
+	return ok
+}
+
+// isIntType returns if t or the type t points to
+// is of a signed or unsigned integer kind
+func isIntType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	}
+	return false
+}
+
+// isFloatType returns if t or the type t points to
+// is of a float kind
+func isFloatType(t reflect.Type) bool {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64
+}
+
+// nonFiniteFloatString returns NaNString or InfString
+// and true if the float val is not finite.
+func (txt *TextRenderer) nonFiniteFloatString(val reflect.Value) (string, bool) {
+	for val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	f := val.Float()
+	switch {
+	case math.IsNaN(f):
+		return txt.NaNString, true
+	case math.IsInf(f, 0):
+		return txt.InfString, true
+	}
+	return "", false
+}
+
+// formatGroupedInt formats the integer val
+// with its digits grouped by thousandsSep
+func formatGroupedInt(val reflect.Value, thousandsSep rune) string {
+	for val.Kind() == reflect.Ptr {
+		val = val.Elem()
+	}
+	var digits string
+	if val.CanInt() {
+		digits = strconv.FormatInt(val.Int(), 10)
+	} else {
+		digits = strconv.FormatUint(val.Uint(), 10)
+	}
+	var b strings.Builder
+	if digits[0] == '-' {
+		b.WriteByte('-')
+		digits = digits[1:]
+	}
+	for i, digit := range digits {
+		if i > 0 && (len(digits)-i)%3 == 0 {
+			b.WriteRune(thousandsSep)
+		}
+		b.WriteRune(digit)
+	}
+	return b.String()
+}
+
+// columnConfig returns the config used to format
+// the values of the column with the index col.
+func (txt *TextRenderer) columnConfig(col int) *strfmt.FormatConfig {
+	floatFormat, hasFloat := txt.ColumnFloatFormat[col]
+	boolStrings, hasBool := txt.ColumnBool[col]
+	if !hasFloat && !hasBool {
+		return txt.config
+	}
+	config := *txt.config
+	if hasFloat {
+		config.Float = floatFormat
+	}
+	if hasBool {
+		config.True, config.False = boolStrings[0], boolStrings[1]
+	}
+	return &config
+}
+
+// RenderFooterRow renders the footer columnValues
+// as an additional row after all other rows.
+func (txt *TextRenderer) RenderFooterRow(columnValues []reflect.Value) error {
+	return txt.RenderRow(columnValues)
+}
+
+// Finish writes the end of the table exactly once.
+// Implements Finisher.
+func (txt *TextRenderer) Finish() error {
+	if txt.finished {
+		return nil
+	}
 	err := txt.format.RenderEndTableText(&txt.buf)
+	if err != nil {
+		return err
+	}
+	txt.finished = true
+	return nil
+}
+
+func (txt *TextRenderer) Result() ([]byte, error) {
+	err := txt.Finish()
 	if err != nil {
 		return nil, err
 	}
@@ -84,7 +359,11 @@ func (txt *TextRenderer) Result() ([]byte, error) {
 }
 
 func (txt *TextRenderer) WriteResultTo(writer io.Writer) error {
-	_, err := txt.buf.WriteTo(writer)
+	err := txt.Finish()
+	if err != nil {
+		return err
+	}
+	_, err = writer.Write(txt.buf.Bytes())
 	return err
 }
 
@@ -0,0 +1,265 @@
+package texttable
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/domonda/go-structtable"
+	"github.com/domonda/go-types/strfmt"
+)
+
+// Align is the horizontal alignment of a column
+type Align int
+
+const (
+	// AlignAuto aligns numeric columns right
+	// and all other columns left.
+	AlignAuto Align = iota
+	AlignLeft
+	AlignRight
+)
+
+// BorderStyle defines the characters used to draw table borders
+type BorderStyle struct {
+	Horizontal  rune
+	Vertical    rune
+	TopLeft     rune
+	TopMid      rune
+	TopRight    rune
+	MidLeft     rune
+	Mid         rune
+	MidRight    rune
+	BottomLeft  rune
+	BottomMid   rune
+	BottomRight rune
+}
+
+var (
+	// ASCIIBorder draws borders with the ASCII characters '-', '|', and '+'
+	ASCIIBorder = &BorderStyle{
+		Horizontal:  '-',
+		Vertical:    '|',
+		TopLeft:     '+',
+		TopMid:      '+',
+		TopRight:    '+',
+		MidLeft:     '+',
+		Mid:         '+',
+		MidRight:    '+',
+		BottomLeft:  '+',
+		BottomMid:   '+',
+		BottomRight: '+',
+	}
+
+	// BoxBorder draws borders with Unicode box-drawing characters
+	BoxBorder = &BorderStyle{
+		Horizontal:  '─',
+		Vertical:    '│',
+		TopLeft:     '┌',
+		TopMid:      '┬',
+		TopRight:    '┐',
+		MidLeft:     '├',
+		Mid:         '┼',
+		MidRight:    '┤',
+		BottomLeft:  '└',
+		BottomMid:   '┴',
+		BottomRight: '┘',
+	}
+)
+
+// Renderer implements structtable.Renderer by rendering
+// a table with aligned columns using monospaced text.
+//
+// Renderer buffers all rows because the widths of the columns
+// are only known after all rows have been rendered.
+// The table is written when Result, WriteResultTo,
+// or WriteResultFile is called.
+// Renderer is not safe for concurrent use,
+// call Reset to reuse it for another table.
+type Renderer struct {
+	*structtable.TextRenderer
+
+	border      *BorderStyle
+	aligns      []Align
+	numericCols []bool
+	header      []string
+	rows        [][]string
+	// footerIndex is the index of the first footer row in rows or -1
+	footerIndex int
+}
+
+func NewRenderer(config *strfmt.FormatConfig) *Renderer {
+	txt := &Renderer{
+		border:      ASCIIBorder,
+		footerIndex: -1,
+	}
+	txt.TextRenderer = structtable.NewTextRenderer(txt, config)
+	return txt
+}
+
+// Reset clears the buffered header and rows
+// so that the Renderer can be reused for another table.
+func (txt *Renderer) Reset() {
+	txt.TextRenderer.Reset()
+	txt.numericCols = nil
+	txt.header = nil
+	txt.rows = nil
+	txt.footerIndex = -1
+}
+
+// WithBorder sets the BorderStyle used to draw the table
+func (txt *Renderer) WithBorder(border *BorderStyle) *Renderer {
+	txt.border = border
+	return txt
+}
+
+// WithColumnAligns sets the alignment of the columns by index.
+// Columns without an alignment use AlignAuto.
+func (txt *Renderer) WithColumnAligns(aligns ...Align) *Renderer {
+	txt.aligns = aligns
+	return txt
+}
+
+// RenderRow tracks which columns are numeric
+// for AlignAuto before rendering the row.
+func (txt *Renderer) RenderRow(columnValues []reflect.Value) error {
+	for len(txt.numericCols) < len(columnValues) {
+		txt.numericCols = append(txt.numericCols, true)
+	}
+	for i, val := range columnValues {
+		if !val.IsValid() {
+			continue
+		}
+		derefType := val.Type()
+		for derefType.Kind() == reflect.Ptr {
+			derefType = derefType.Elem()
+		}
+		switch derefType.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+		default:
+			txt.numericCols[i] = false
+		}
+	}
+	return txt.TextRenderer.RenderRow(columnValues)
+}
+
+// RenderFooterRow renders the footer columnValues
+// separated by a horizontal line from the other rows.
+func (txt *Renderer) RenderFooterRow(columnValues []reflect.Value) error {
+	if txt.footerIndex < 0 {
+		txt.footerIndex = len(txt.rows)
+	}
+	return txt.TextRenderer.RenderRow(columnValues)
+}
+
+func (txt *Renderer) RenderBeginTableText(writer io.Writer) error {
+	return nil
+}
+
+func (txt *Renderer) RenderHeaderRowText(writer io.Writer, columnTitles []string) error {
+	txt.header = columnTitles
+	return nil
+}
+
+func (txt *Renderer) RenderRowText(writer io.Writer, fields []string) error {
+	txt.rows = append(txt.rows, fields)
+	return nil
+}
+
+// RenderEndTableText writes the buffered header and rows
+// as table with aligned columns.
+func (txt *Renderer) RenderEndTableText(writer io.Writer) error {
+	numCols := len(txt.header)
+	for _, row := range txt.rows {
+		numCols = max(numCols, len(row))
+	}
+	if numCols == 0 {
+		return nil
+	}
+	widths := make([]int, numCols)
+	for i, title := range txt.header {
+		widths[i] = max(widths[i], utf8.RuneCountInString(cellText(title)))
+	}
+	for _, row := range txt.rows {
+		for i, field := range row {
+			widths[i] = max(widths[i], utf8.RuneCountInString(cellText(field)))
+		}
+	}
+
+	var b strings.Builder
+	txt.writeLine(&b, widths, txt.border.TopLeft, txt.border.TopMid, txt.border.TopRight)
+	if txt.header != nil {
+		txt.writeRow(&b, widths, txt.header, false)
+		txt.writeLine(&b, widths, txt.border.MidLeft, txt.border.Mid, txt.border.MidRight)
+	}
+	for i, row := range txt.rows {
+		if i == txt.footerIndex && i > 0 {
+			txt.writeLine(&b, widths, txt.border.MidLeft, txt.border.Mid, txt.border.MidRight)
+		}
+		txt.writeRow(&b, widths, row, true)
+	}
+	txt.writeLine(&b, widths, txt.border.BottomLeft, txt.border.BottomMid, txt.border.BottomRight)
+
+	_, err := io.WriteString(writer, b.String())
+	return err
+}
+
+func (txt *Renderer) writeLine(b *strings.Builder, widths []int, left, mid, right rune) {
+	b.WriteRune(left)
+	for i, width := range widths {
+		if i > 0 {
+			b.WriteRune(mid)
+		}
+		b.WriteString(strings.Repeat(string(txt.border.Horizontal), width+2))
+	}
+	b.WriteRune(right)
+	b.WriteByte('\n')
+}
+
+func (txt *Renderer) writeRow(b *strings.Builder, widths []int, fields []string, align bool) {
+	b.WriteRune(txt.border.Vertical)
+	for i, width := range widths {
+		var field string
+		if i < len(fields) {
+			field = cellText(fields[i])
+		}
+		padding := strings.Repeat(" ", width-utf8.RuneCountInString(field))
+		b.WriteByte(' ')
+		if align && txt.columnAlign(i) == AlignRight {
+			b.WriteString(padding)
+			b.WriteString(field)
+		} else {
+			b.WriteString(field)
+			b.WriteString(padding)
+		}
+		b.WriteByte(' ')
+		b.WriteRune(txt.border.Vertical)
+	}
+	b.WriteByte('\n')
+}
+
+func (txt *Renderer) columnAlign(col int) Align {
+	if col < len(txt.aligns) && txt.aligns[col] != AlignAuto {
+		return txt.aligns[col]
+	}
+	if col < len(txt.numericCols) && txt.numericCols[col] {
+		return AlignRight
+	}
+	return AlignLeft
+}
+
+func (*Renderer) MIMEType() string {
+	return "text/plain; charset=UTF-8"
+}
+
+// cellText replaces newlines with spaces
+// so that a field fits into a single line
+func cellText(field string) string {
+	if !strings.ContainsAny(field, "\r\n") {
+		return field
+	}
+	return strings.Join(strings.Fields(field), " ")
+}
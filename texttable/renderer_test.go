@@ -0,0 +1,63 @@
+package texttable
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-structtable"
+	"github.com/domonda/go-types/strfmt"
+)
+
+func TestRenderer(t *testing.T) {
+	type row struct {
+		Name  string
+		Count int
+	}
+	rows := []row{{"Apple", 3}, {"Kiwi", 12}}
+	total := func(any) []reflect.Value {
+		return []reflect.Value{reflect.ValueOf("Sum"), reflect.ValueOf(15)}
+	}
+
+	renderer := NewRenderer(strfmt.NewFormatConfig())
+	err := structtable.RenderWithFooter(renderer, rows, true, structtable.DefaultReflectColumnTitles, total)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+
+	const expected = `+-------+-------+
+| Name  | Count |
++-------+-------+
+| Apple |     3 |
+| Kiwi  |    12 |
++-------+-------+
+| Sum   |    15 |
++-------+-------+
+`
+	assert.Equal(t, expected, string(result))
+
+	result, err = renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, expected, string(result), "Result is idempotent")
+	var buf strings.Builder
+	err = renderer.WriteResultTo(&buf)
+	assert.NoError(t, err, "WriteResultTo")
+	assert.Equal(t, expected, buf.String(), "WriteResultTo after Result")
+	err = renderer.RenderRow(nil)
+	assert.ErrorIs(t, err, structtable.ErrFinished, "RenderRow after Finish")
+
+	renderer = NewRenderer(strfmt.NewFormatConfig()).WithBorder(BoxBorder).WithColumnAligns(AlignRight, AlignLeft)
+	err = structtable.Render(renderer, rows, false, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err = renderer.Result()
+	assert.NoError(t, err, "Result")
+
+	const expectedBox = `┌───────┬────┐
+│ Apple │ 3  │
+│  Kiwi │ 12 │
+└───────┴────┘
+`
+	assert.Equal(t, expectedBox, string(result))
+}
@@ -7,15 +7,15 @@ func (t StringsTable) NumRows() int {
 	return len(t)
 }
 
-// func (t StringsTable) NumCols() int {
-// 	cols := 0
-// 	for _, row := range t {
-// 		if n := len(row); n > cols {
-// 			cols = n
-// 		}
-// 	}
-// 	return cols
-// }
+func (t StringsTable) NumCols() int {
+	cols := 0
+	for _, row := range t {
+		if n := len(row); n > cols {
+			cols = n
+		}
+	}
+	return cols
+}
 
 func (t StringsTable) NumRowCells(row int) int {
 	if row < 0 || row >= len(t) {
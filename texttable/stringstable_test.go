@@ -0,0 +1,31 @@
+package texttable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStringsTableNumCols(t *testing.T) {
+	testCases := map[string]struct {
+		table StringsTable
+		want  int
+	}{
+		"nil":           {nil, 0},
+		"empty rows":    {StringsTable{{}, {}}, 0},
+		"uniform":       {StringsTable{{"a", "b"}, {"c", "d"}}, 2},
+		"longest first": {StringsTable{{"a", "b", "c"}, {"d"}}, 3},
+		"longest last":  {StringsTable{{"a"}, {}, {"b", "c", "d"}}, 3},
+		"longest inner": {StringsTable{{"a"}, {"b", "c", "d", "e"}, {"f", "g"}}, 4},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tc.want, tc.table.NumCols())
+		})
+	}
+
+	ragged := StringsTable{{"a"}, {"b", "c", "d"}}
+	assert.Equal(t, 1, ragged.NumRowCells(0), "short row keeps its cell count")
+	assert.False(t, ragged.CellExists(0, ragged.NumCols()-1), "no cell in short row")
+	assert.Equal(t, "", ragged.CellText(0, 2))
+}
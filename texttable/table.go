@@ -2,7 +2,10 @@ package texttable
 
 type Table interface {
 	NumRows() int
-	// NumCols() int
+
+	// NumCols returns the number of columns of the table
+	// which is the maximum number of cells of all rows.
+	NumCols() int
 
 	// NumRowCells returns the number of cells in a row.
 	// Rows may have less cells than the whole table has columns.
@@ -0,0 +1,31 @@
+package structtable
+
+import (
+	"reflect"
+
+	"github.com/domonda/go-errs"
+)
+
+// OverrideTitles returns a ColumnMapper that uses the columns
+// and RowReflector of columnMapper, but with the passed titles
+// instead of the titles of columnMapper, for example localized titles.
+// This way columnMapper decides which fields become columns in what order
+// and titles what the header says.
+//
+// An error is returned if the number of titles differs
+// from the number of columns of columnMapper for structType.
+// The returned ColumnMapper is bound to structType,
+// for other struct types it returns the titles of columnMapper.
+func OverrideTitles(columnMapper ColumnMapper, structType reflect.Type, titles ...string) (ColumnMapper, error) {
+	columnTitles, _ := columnMapper.ColumnTitlesAndRowReflector(structType)
+	if len(titles) != len(columnTitles) {
+		return nil, errs.Errorf("%d titles passed for %d columns of %s", len(titles), len(columnTitles), structType)
+	}
+	return ColumnMapperFunc(func(t reflect.Type) ([]string, RowReflector) {
+		columnTitles, rowReflector := columnMapper.ColumnTitlesAndRowReflector(t)
+		if t != structType || len(titles) != len(columnTitles) {
+			return columnTitles, rowReflector
+		}
+		return titles, rowReflector
+	}), nil
+}
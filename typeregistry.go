@@ -0,0 +1,95 @@
+package structtable
+
+import (
+	"maps"
+	"reflect"
+
+	"github.com/domonda/go-types/strfmt"
+)
+
+// TypeRegistration defines how values of a type
+// are rendered by the different formats.
+type TypeRegistration struct {
+	// Text formats values of the type for text based formats
+	// like CSV, text, and HTML tables.
+	// If nil, then the format's default formatting is used.
+	Text strfmt.Formatter
+	// ExcelCell is used by the excel package to write
+	// values of the type to cells and must implement
+	// excel.ExcelCellWriter. It is declared as any
+	// because the excel package depends on this package.
+	// If nil, then the Excel renderer's default is used.
+	ExcelCell any
+	// RawHTML marks the text formatted values of the type
+	// as trusted HTML that HTMLRenderer writes without escaping.
+	RawHTML bool
+}
+
+// TypeRegistry holds TypeRegistrations so that a custom type
+// can be registered once for all formats instead of
+// configuring the type maps of every format separately.
+// The registry is applied to the existing maps of a renderer
+// with its SetTypeRegistry method.
+//
+// The zero value is an empty registry ready to use.
+// TypeRegistry is not safe for concurrent modification.
+type TypeRegistry struct {
+	types map[reflect.Type]TypeRegistration
+}
+
+// NewTypeRegistry returns an empty TypeRegistry
+func NewTypeRegistry() *TypeRegistry {
+	return new(TypeRegistry)
+}
+
+// Register registers how values of type t are rendered
+// replacing any former registration of t.
+// Returns the registry to allow chaining.
+func (r *TypeRegistry) Register(t reflect.Type, registration TypeRegistration) *TypeRegistry {
+	if r.types == nil {
+		r.types = make(map[reflect.Type]TypeRegistration)
+	}
+	r.types[t] = registration
+	return r
+}
+
+// Lookup returns the registration of type t
+// and if t is registered.
+func (r *TypeRegistry) Lookup(t reflect.Type) (TypeRegistration, bool) {
+	registration, ok := r.types[t]
+	return registration, ok
+}
+
+// Registrations returns a copy of all registrations by type.
+func (r *TypeRegistry) Registrations() map[reflect.Type]TypeRegistration {
+	return maps.Clone(r.types)
+}
+
+// FormatConfig returns a copy of config with the registered
+// Text formatters added to the copied TypeFormatters
+// so that config itself is not modified.
+func (r *TypeRegistry) FormatConfig(config *strfmt.FormatConfig) *strfmt.FormatConfig {
+	result := *config
+	result.TypeFormatters = maps.Clone(config.TypeFormatters)
+	for t, registration := range r.types {
+		if registration.Text == nil {
+			continue
+		}
+		if result.TypeFormatters == nil {
+			result.TypeFormatters = make(map[reflect.Type]strfmt.Formatter)
+		}
+		result.TypeFormatters[t] = registration.Text
+	}
+	return &result
+}
+
+// RawHTMLTypes returns the set of types registered with RawHTML.
+func (r *TypeRegistry) RawHTMLTypes() map[reflect.Type]bool {
+	rawTypes := make(map[reflect.Type]bool)
+	for t, registration := range r.types {
+		if registration.RawHTML {
+			rawTypes[t] = true
+		}
+	}
+	return rawTypes
+}
@@ -0,0 +1,55 @@
+package structtable
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-types/strfmt"
+)
+
+type registryTestSKU string
+
+func formatRegistryTestSKU(val reflect.Value, config *strfmt.FormatConfig) string {
+	return "<b>" + strings.ToUpper(val.String()) + "</b>"
+}
+
+type htmlTestFormat struct{}
+
+func (htmlTestFormat) RenderBeforeTable(writer io.Writer) error { return nil }
+
+func TestTypeRegistry(t *testing.T) {
+	type row struct {
+		SKU  registryTestSKU
+		Name string
+	}
+	registry := NewTypeRegistry().Register(
+		reflect.TypeOf(registryTestSKU("")),
+		TypeRegistration{Text: strfmt.FormatterFunc(formatRegistryTestSKU)},
+	)
+	rows := []row{{"ab1", "<i>"}}
+
+	config := strfmt.NewFormatConfig()
+	txt := newJoinRenderer(config)
+	txt.SetTypeRegistry(registry)
+	result, err := RenderBytes(txt, rows, false, DefaultReflectColumnTitles)
+	assert.NoError(t, err)
+	assert.Equal(t, "<b>AB1</b>,<i>\n", string(result))
+	assert.NotContains(t, config.TypeFormatters, reflect.TypeOf(registryTestSKU("")), "passed config not modified")
+
+	htm := NewHTMLRenderer(htmlTestFormat{}, &HTMLTableConfig{}, strfmt.NewFormatConfig())
+	htm.SetTypeRegistry(registry)
+	result, err = RenderBytes(htm, rows, false, DefaultReflectColumnTitles)
+	assert.NoError(t, err)
+	assert.Contains(t, string(result), "<td>&lt;b&gt;AB1&lt;/b&gt;</td><td>&lt;i&gt;</td>", "not registered as RawHTML")
+
+	registry.Register(reflect.TypeOf(registryTestSKU("")), TypeRegistration{Text: strfmt.FormatterFunc(formatRegistryTestSKU), RawHTML: true})
+	htm = NewHTMLRenderer(htmlTestFormat{}, &HTMLTableConfig{}, strfmt.NewFormatConfig())
+	htm.SetTypeRegistry(registry)
+	result, err = RenderBytes(htm, rows, false, DefaultReflectColumnTitles)
+	assert.NoError(t, err)
+	assert.Contains(t, string(result), "<td><b>AB1</b></td><td>&lt;i&gt;</td>")
+}
@@ -0,0 +1,84 @@
+package wikitable
+
+import (
+	"io"
+	"strings"
+
+	"github.com/domonda/go-structtable"
+	"github.com/domonda/go-types/strfmt"
+)
+
+// Renderer implements structtable.Renderer by rendering
+// a Confluence or Jira wiki markup table
+// with a header row like "||Name||Count||"
+// and rows like "|Apple|1|".
+//
+// Values are formatted with the text formatters of the FormatConfig
+// and escaped with Escape.
+//
+// Renderer is not safe for concurrent use,
+// call Reset to reuse it for another table.
+type Renderer struct {
+	*structtable.TextRenderer
+}
+
+func NewRenderer(config *strfmt.FormatConfig) *Renderer {
+	wiki := new(Renderer)
+	wiki.TextRenderer = structtable.NewTextRenderer(wiki, config)
+	return wiki
+}
+
+func (*Renderer) RenderBeginTableText(writer io.Writer) error {
+	return nil
+}
+
+func (*Renderer) RenderHeaderRowText(writer io.Writer, columnTitles []string) error {
+	return writeRow(writer, columnTitles, "||")
+}
+
+func (*Renderer) RenderRowText(writer io.Writer, fields []string) error {
+	return writeRow(writer, fields, "|")
+}
+
+func (*Renderer) RenderEndTableText(writer io.Writer) error {
+	return nil
+}
+
+func (*Renderer) MIMEType() string {
+	return "text/plain; charset=UTF-8"
+}
+
+func writeRow(writer io.Writer, fields []string, delimiter string) error {
+	var b strings.Builder
+	b.WriteString(delimiter)
+	for _, field := range fields {
+		b.WriteString(Escape(field))
+		b.WriteString(delimiter)
+	}
+	b.WriteByte('\n')
+	_, err := io.WriteString(writer, b.String())
+	return err
+}
+
+var escaper = strings.NewReplacer(
+	`\`, `&#92;`,
+	`|`, `\|`,
+	"\r\n", `\\`,
+	"\n", `\\`,
+	"\r", `\\`,
+)
+
+// Escape escapes the cell delimiter "|" as `\|`
+// and replaces line breaks with the forced line break `\\`
+// so that str stays within its cell.
+// Backslashes are written as the character reference `&#92;`
+// so that they don't escape the following delimiter
+// or form a line break, because `\\` is one in wiki markup.
+// An empty string is returned as single space
+// because empty cells would be merged with the next cell.
+func Escape(str string) string {
+	if str == "" {
+		return " "
+	}
+	return escaper.Replace(str)
+}
@@ -0,0 +1,38 @@
+package wikitable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-structtable"
+	"github.com/domonda/go-types/strfmt"
+)
+
+func TestRenderer(t *testing.T) {
+	type row struct {
+		Name  string
+		Count int
+		Note  string `col:"Note|Remark"`
+	}
+	rows := []row{
+		{"Apple", 1, "a|b"},
+		{"Pear", 2, ""},
+		{"Plum", 3, "Line1\nLine2"},
+		{"Path", 4, `C:\dir\|x\`},
+	}
+
+	renderer := NewRenderer(strfmt.NewFormatConfig())
+	err := structtable.Render(renderer, rows, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+
+	const expected = `||Name||Count||Note\|Remark||
+|Apple|1|a\|b|
+|Pear|2| |
+|Plum|3|Line1\\Line2|
+|Path|4|C:&#92;dir&#92;\|x&#92;|
+`
+	assert.Equal(t, expected, string(result))
+}
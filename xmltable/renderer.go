@@ -0,0 +1,140 @@
+package xmltable
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/domonda/go-structtable"
+	"github.com/domonda/go-types/strfmt"
+)
+
+// Renderer implements structtable.Renderer by rendering
+// a root element with one child element per row
+// containing one element per column named by the column title.
+//
+// Column titles are sanitized to valid XML names with SanitizeName.
+// Without a header row the column elements are named
+// "column1", "column2", and so on.
+// Values are formatted with the text formatters of the FormatConfig
+// and escaped as XML character data.
+//
+// Renderer is not safe for concurrent use,
+// call Reset to reuse it for another table.
+type Renderer struct {
+	*structtable.TextRenderer
+
+	rootElement  string
+	rowElement   string
+	names        []string
+	beginWritten bool
+}
+
+// NewRenderer returns a Renderer with "table" as root
+// and "row" as row element name.
+func NewRenderer(config *strfmt.FormatConfig) *Renderer {
+	x := &Renderer{
+		rootElement: "table",
+		rowElement:  "row",
+	}
+	x.TextRenderer = structtable.NewTextRenderer(x, config)
+	return x
+}
+
+// WithRootElement sets the name of the root element
+// sanitized with SanitizeName.
+func (x *Renderer) WithRootElement(name string) *Renderer {
+	x.rootElement = SanitizeName(name)
+	return x
+}
+
+// WithRowElement sets the name of the row elements
+// sanitized with SanitizeName.
+func (x *Renderer) WithRowElement(name string) *Renderer {
+	x.rowElement = SanitizeName(name)
+	return x
+}
+
+// Reset clears the rendered XML and column names
+// so that the Renderer can be reused for another table.
+func (x *Renderer) Reset() {
+	x.TextRenderer.Reset()
+	x.names = nil
+	x.beginWritten = false
+}
+
+func (x *Renderer) RenderBeginTableText(writer io.Writer) error {
+	x.beginWritten = true
+	_, err := fmt.Fprintf(writer, "%s<%s>\n", xml.Header, x.rootElement)
+	return err
+}
+
+func (x *Renderer) RenderHeaderRowText(writer io.Writer, columnTitles []string) error {
+	x.names = make([]string, len(columnTitles))
+	for i, title := range columnTitles {
+		x.names[i] = SanitizeName(title)
+	}
+	return nil
+}
+
+func (x *Renderer) RenderRowText(writer io.Writer, fields []string) error {
+	var b strings.Builder
+	fmt.Fprintf(&b, "\t<%s>\n", x.rowElement)
+	for i, field := range fields {
+		name := fmt.Sprintf("column%d", i+1)
+		if i < len(x.names) {
+			name = x.names[i]
+		}
+		fmt.Fprintf(&b, "\t\t<%s>", name)
+		xml.EscapeText(&b, []byte(field))
+		fmt.Fprintf(&b, "</%s>\n", name)
+	}
+	fmt.Fprintf(&b, "\t</%s>\n", x.rowElement)
+	_, err := io.WriteString(writer, b.String())
+	return err
+}
+
+// RenderEndTableText closes the root element
+// that is also written for a table without rows.
+func (x *Renderer) RenderEndTableText(writer io.Writer) error {
+	if !x.beginWritten {
+		err := x.RenderBeginTableText(writer)
+		if err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintf(writer, "</%s>\n", x.rootElement)
+	return err
+}
+
+func (*Renderer) MIMEType() string {
+	return "application/xml"
+}
+
+// SanitizeName returns name as valid XML element name
+// by replacing whitespace with underscores and dropping
+// all other characters that are not valid in XML names.
+// An underscore is prepended if the name is empty,
+// does not start with a letter or underscore,
+// or starts with the reserved prefix "xml".
+// Colons are dropped because they separate namespace prefixes.
+func SanitizeName(name string) string {
+	var b strings.Builder
+	b.Grow(len(name) + 1)
+	for _, r := range strings.TrimSpace(name) {
+		switch {
+		case unicode.IsSpace(r):
+			b.WriteByte('_')
+		case unicode.IsLetter(r), unicode.IsDigit(r), r == '_', r == '-', r == '.':
+			b.WriteRune(r)
+		}
+	}
+	sanitized := b.String()
+	if sanitized == "" || !(unicode.IsLetter([]rune(sanitized)[0]) || sanitized[0] == '_') ||
+		strings.HasPrefix(strings.ToLower(sanitized), "xml") {
+		return "_" + sanitized
+	}
+	return sanitized
+}
@@ -0,0 +1,76 @@
+package xmltable
+
+import (
+	"encoding/xml"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/domonda/go-structtable"
+	"github.com/domonda/go-types/strfmt"
+)
+
+func TestRenderer(t *testing.T) {
+	type row struct {
+		Name    string
+		Count   int
+		Comment *string `col:"1st comment"`
+	}
+	comment := "a < b & \"c\""
+	rows := []row{{"Apple", 3, &comment}, {"Kiwi", 12, nil}}
+
+	renderer := NewRenderer(strfmt.NewEnglishFormatConfig()).WithRootElement("fruits").WithRowElement("fruit")
+	err := structtable.Render(renderer, rows, true, structtable.DefaultReflectColumnTitles)
+	assert.NoError(t, err, "Render")
+	result, err := renderer.Result()
+	assert.NoError(t, err, "Result")
+
+	const expected = xml.Header + `<fruits>
+	<fruit>
+		<Name>Apple</Name>
+		<Count>3</Count>
+		<_1st_comment>a &lt; b &amp; &#34;c&#34;</_1st_comment>
+	</fruit>
+	<fruit>
+		<Name>Kiwi</Name>
+		<Count>12</Count>
+		<_1st_comment></_1st_comment>
+	</fruit>
+</fruits>
+`
+	assert.Equal(t, expected, string(result))
+	assert.Equal(t, "application/xml", renderer.MIMEType())
+
+	var parsed struct {
+		Fruits []struct {
+			Name string
+		} `xml:"fruit"`
+	}
+	assert.NoError(t, xml.Unmarshal(result, &parsed), "valid XML")
+	assert.Len(t, parsed.Fruits, 2)
+
+	renderer.Reset()
+	result, err = renderer.Result()
+	assert.NoError(t, err, "Result")
+	assert.Equal(t, xml.Header+"<fruits>\n</fruits>\n", string(result), "empty table")
+}
+
+func TestSanitizeName(t *testing.T) {
+	tests := map[string]string{
+		"Name":         "Name",
+		"First Name":   "First_Name",
+		"Price (€)":    "Price_",
+		"1st":          "_1st",
+		"":             "_",
+		"xmlData":      "_xmlData",
+		"ns:element":   "nselement",
+		"Größe":        "Größe",
+		"-dash":        "_-dash",
+		"  trimmed  ":  "trimmed",
+		"a.b-c_d":      "a.b-c_d",
+		"tab\tnewline": "tab_newline",
+	}
+	for name, want := range tests {
+		assert.Equal(t, want, SanitizeName(name), name)
+	}
+}